@@ -0,0 +1,9 @@
+data "netbird_events" "peers_added" {
+  activity_code = "peer.user.add"
+  since         = "2025-01-01T00:00:00Z"
+  limit         = 100
+}
+
+output "peers_added_by" {
+  value = data.netbird_events.peers_added.events[*].initiator_email
+}
@@ -0,0 +1,7 @@
+data "netbird_geo_locations" "germany" {
+  country_code = "DE"
+}
+
+output "german_cities" {
+  value = data.netbird_geo_locations.germany.cities[*].city_name
+}
@@ -0,0 +1,5 @@
+data "netbird_group_memberships" "example" {}
+
+output "group_peers" {
+  value = { for m in data.netbird_group_memberships.example.memberships : m.group_name => m.peer_ids }
+}
@@ -0,0 +1,12 @@
+data "netbird_network" "example" {
+  name = "TF Test"
+}
+
+data "netbird_network_routers" "example" {
+  network_id = data.netbird_network.example.id
+  # All filters are optional, routers matching all included criteria are returned in ids field
+  peer       = "d057h0jl0ubs73cftnp0"
+  enabled    = true
+  metric     = 9999
+  masquerade = true
+}
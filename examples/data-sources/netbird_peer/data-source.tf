@@ -12,3 +12,13 @@ data "netbird_peer" "example" {
 data "netbird_peer" "example" {
   id = "d057h0jl0ubs73cftnp0"
 }
+
+# Retrieve by Hostname, ignoring any appended domain suffix
+data "netbird_peer" "example" {
+  hostname_prefix = "web-01"
+}
+
+# Retrieve by device serial number
+data "netbird_peer" "example" {
+  serial_number = "C02XL0GHJGH5"
+}
@@ -0,0 +1,5 @@
+data "netbird_peer_dns_conflicts" "example" {}
+
+output "dns_conflicts" {
+  value = data.netbird_peer_dns_conflicts.example.conflicts
+}
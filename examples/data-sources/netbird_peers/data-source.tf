@@ -20,6 +20,18 @@ data "netbird_peers" "example" {
   login_expiration_enabled      = false
   login_expired                 = false
   geoname_id                    = 360630
+  # Skip transient peers registered with ephemeral setup keys
+  exclude_ephemeral = true
   # Peers containing all groups mentioned are included, even if they have more groups attached
   groups = [data.netbird_group.example.id]
 }
+
+# Peers waiting for approval when peer approval is enabled for the account
+data "netbird_peers" "pending" {
+  approval_required = true
+}
+
+# Peers in groups looked up by name, this adds a Groups API request
+data "netbird_peers" "devs" {
+  group_names = ["devs"]
+}
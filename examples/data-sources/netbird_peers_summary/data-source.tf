@@ -0,0 +1,5 @@
+data "netbird_peers_summary" "example" {}
+
+output "connected_peers" {
+  value = "${data.netbird_peers_summary.example.connected}/${data.netbird_peers_summary.example.total}"
+}
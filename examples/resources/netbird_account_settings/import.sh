@@ -0,0 +1,5 @@
+terraform import netbird_account_settings.example account
+
+# The account ID can be used as well, for example
+
+terraform import netbird_account_settings.example cvr9ibrl0ubs73c11gr0
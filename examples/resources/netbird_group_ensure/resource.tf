@@ -0,0 +1,3 @@
+resource "netbird_group_ensure" "example" {
+  name = "Developers"
+}
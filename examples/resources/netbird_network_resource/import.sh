@@ -2,4 +2,8 @@ terraform import netbird_network_resource.example network_id/network_resource_id
 
 # For example
 
-terraform import netbird_network_resource.example cvr9ibrl0ubs73c11gr0/cvr9ktjl0ubs73c11h70
\ No newline at end of file
+terraform import netbird_network_resource.example cvr9ibrl0ubs73c11gr0/cvr9ktjl0ubs73c11h70
+
+# Importing with only the network ID fails and lists the resources of the network with their import IDs
+
+terraform import netbird_network_resource.example cvr9ibrl0ubs73c11gr0
\ No newline at end of file
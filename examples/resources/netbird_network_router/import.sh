@@ -2,4 +2,8 @@ terraform import netbird_network_router.example network_id/network_router_id
 
 # For example
 
-terraform import netbird_network_router.example cvr9ibrl0ubs73c11gr0/cvr9ic3l0ubs73c11gs0
\ No newline at end of file
+terraform import netbird_network_router.example cvr9ibrl0ubs73c11gr0/cvr9ic3l0ubs73c11gs0
+
+# Importing with only the network ID fails and lists the routers of the network with their import IDs
+
+terraform import netbird_network_router.example cvr9ibrl0ubs73c11gr0
\ No newline at end of file
@@ -0,0 +1,9 @@
+terraform import netbird_peer.example peer_id
+
+# Or by device serial number, the serial number must not be empty and must match exactly one peer
+terraform import netbird_peer.example serial=serial_number
+
+# For example
+
+terraform import netbird_peer.example d057h0jl0ubs73cftnp0
+terraform import netbird_peer.example serial=C02XL0GHJGH5
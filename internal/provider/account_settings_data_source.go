@@ -6,7 +6,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -135,18 +134,18 @@ func (d *AccountSettingsDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *AccountSettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -160,19 +159,18 @@ func (d *AccountSettingsDataSource) Read(ctx context.Context, req datasource.Rea
 	}
 
 	accounts, err := d.client.Accounts.List(ctx)
-	account := accounts[0]
-
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			resp.State.RemoveResource(ctx)
-			return
-		} else {
-			resp.Diagnostics.AddError("Error getting AccountSettings", err.Error())
-		}
+		resp.Diagnostics.AddError("Error getting AccountSettings", formatAPIError(err))
+		return
+	}
+
+	account, di := firstAccount(accounts)
+	resp.Diagnostics.Append(di...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(accountAPIToTerraform(ctx, &account, &data)...)
+	resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -6,9 +6,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,6 +31,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AccountSettings{}
 var _ resource.ResourceWithImportState = &AccountSettings{}
+var _ resource.ResourceWithConfigValidators = &AccountSettings{}
+var _ resource.ResourceWithModifyPlan = &AccountSettings{}
 
 func NewAccountSettings() resource.Resource {
 	return &AccountSettings{}
@@ -36,9 +41,12 @@ func NewAccountSettings() resource.Resource {
 // AccountSettings defines the resource implementation.
 type AccountSettings struct {
 	client *netbird.Client
+
+	// deployment is the deployment kind of the Management API, cloud only settings are ignored by self-hosted servers
+	deployment string
 }
 
-// AccountSettingsModel describes the resource data model.
+// AccountSettingsModel describes the account settings data model.
 type AccountSettingsModel struct {
 	Id                                 types.String `tfsdk:"id"`
 	JwtAllowGroups                     types.List   `tfsdk:"jwt_allow_groups"`
@@ -64,6 +72,13 @@ type AccountSettingsModel struct {
 	PeerExposeGroups                   types.List   `tfsdk:"peer_expose_groups"`
 }
 
+// AccountSettingsResourceModel describes the resource data model, adding provider-side attributes to the account settings.
+type AccountSettingsResourceModel struct {
+	AccountSettingsModel
+	ApplyToExistingPeers types.Bool `tfsdk:"apply_to_existing_peers"`
+	AdoptOnly            types.Bool `tfsdk:"adopt_only"`
+}
+
 func (r *AccountSettings) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_account_settings"
 }
@@ -142,7 +157,7 @@ func (r *AccountSettings) Schema(ctx context.Context, req resource.SchemaRequest
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
 			"peer_approval_enabled": schema.BoolAttribute{
-				MarkdownDescription: "(Cloud only) Enables or disables peer approval globally. If enabled, all peers added will be in pending state until approved by an admin.",
+				MarkdownDescription: "(Cloud only) Enables or disables peer approval globally. If enabled, all peers added will be in pending state until approved by an admin. Enabling it warns when the provider `deployment` is `self-hosted`.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
@@ -209,6 +224,14 @@ func (r *AccountSettings) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:            true,
 				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()},
 			},
+			"adopt_only": schema.BoolAttribute{
+				MarkdownDescription: "If enabled, creating the resource adopts the current account settings without writing them, and fails naming the configured settings that differ from the account, lists are compared ignoring the order of their elements. Updates after creation are applied as usual.",
+				Optional:            true,
+			},
+			"apply_to_existing_peers": schema.BoolAttribute{
+				MarkdownDescription: "If enabled, peer_inactivity_expiration_enabled is also applied to existing peers added with SSO login whenever account settings are applied. Peers added with setup keys do not support inactivity expiration and are not changed.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -219,24 +242,39 @@ func (r *AccountSettings) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.deployment = data.deployment
 }
 
 func accountAPIToTerraform(ctx context.Context, account *api.Account, data *AccountSettingsModel) diag.Diagnostics {
 	var ret diag.Diagnostics
+	if account.Settings.Extra == nil {
+		ret.AddError("Unexpected Value", fmt.Sprintf("Settings of account %s are missing extra settings", account.Id))
+		return ret
+	}
+	jwtAllowGroups, d := types.ListValueFrom(ctx, types.StringType, account.Settings.JwtAllowGroups)
+	ret.Append(d...)
+	logsGroups, d := types.ListValueFrom(ctx, types.StringType, account.Settings.Extra.NetworkTrafficLogsGroups)
+	ret.Append(d...)
+	peerExposeGroups, d := types.ListValueFrom(ctx, types.StringType, account.Settings.PeerExposeGroups)
+	ret.Append(d...)
+	if ret.HasError() {
+		return ret
+	}
+
 	data.Id = types.StringValue(account.Id)
-	data.JwtAllowGroups, ret = types.ListValueFrom(ctx, types.StringType, account.Settings.JwtAllowGroups)
+	data.JwtAllowGroups = jwtAllowGroups
 	data.JwtGroupsClaimName = types.StringPointerValue(account.Settings.JwtGroupsClaimName)
 	data.PeerLoginExpiration = types.Int32Value(int32(account.Settings.PeerLoginExpiration))
 	data.PeerInactivityExpiration = types.Int32Value(int32(account.Settings.PeerInactivityExpiration))
@@ -254,12 +292,9 @@ func accountAPIToTerraform(ctx context.Context, account *api.Account, data *Acco
 	data.NetworkRange = types.StringPointerValue(account.Settings.NetworkRange)
 	data.LazyConnectionEnabled = types.BoolPointerValue(account.Settings.LazyConnectionEnabled)
 	data.UserApprovalRequired = types.BoolValue(account.Settings.Extra.UserApprovalRequired)
-	logsGroups, d := types.ListValueFrom(ctx, types.StringType, account.Settings.Extra.NetworkTrafficLogsGroups)
-	ret.Append(d...)
 	data.NetworkTrafficLogsGroups = logsGroups
 	data.PeerExposeEnabled = types.BoolValue(account.Settings.PeerExposeEnabled)
-	data.PeerExposeGroups, d = types.ListValueFrom(ctx, types.StringType, account.Settings.PeerExposeGroups)
-	ret.Append(d...)
+	data.PeerExposeGroups = peerExposeGroups
 	return ret
 }
 
@@ -293,8 +328,169 @@ func accountTerraformToAPI(ctx context.Context, account *api.Account, data Accou
 	}
 }
 
+// accountSettingsConfigValidator warns about expiration periods set for disabled expirations, where they have no effect.
+type accountSettingsConfigValidator struct{}
+
+func (v accountSettingsConfigValidator) Description(ctx context.Context) string {
+	return "expiration periods only apply when the expiration is enabled"
+}
+
+func (v accountSettingsConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v accountSettingsConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AccountSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.PeerLoginExpiration.IsNull() && !data.PeerLoginExpirationEnabled.IsNull() && !data.PeerLoginExpirationEnabled.IsUnknown() && !data.PeerLoginExpirationEnabled.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("peer_login_expiration"),
+			"Ineffective peer_login_expiration",
+			"peer_login_expiration has no effect while peer_login_expiration_enabled is false.",
+		)
+	}
+	if !data.PeerInactivityExpiration.IsNull() && !data.PeerInactivityExpirationEnabled.IsNull() && !data.PeerInactivityExpirationEnabled.IsUnknown() && !data.PeerInactivityExpirationEnabled.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("peer_inactivity_expiration"),
+			"Ineffective peer_inactivity_expiration",
+			"peer_inactivity_expiration has no effect while peer_inactivity_expiration_enabled is false.",
+		)
+	}
+}
+
+func (r *AccountSettings) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{accountSettingsConfigValidator{}}
+}
+
+// firstAccount returns the account the token has access to.
+func firstAccount(accounts []api.Account) (*api.Account, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	if len(accounts) == 0 {
+		ret.AddError("No Account Found", "no accounts accessible with the provided token")
+		return nil, ret
+	}
+	return &accounts[0], ret
+}
+
+// accountSettingsDiffering returns the names of the configured attributes of data that differ from current,
+// lists are compared ignoring order.
+func accountSettingsDiffering(data, current AccountSettingsModel) []string {
+	var ret []string
+	dataValue, currentValue := reflect.ValueOf(data), reflect.ValueOf(current)
+	for i := range dataValue.NumField() {
+		name := dataValue.Type().Field(i).Tag.Get("tfsdk")
+		v, ok := dataValue.Field(i).Interface().(attr.Value)
+		if name == "id" || !ok || v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		if !accountSettingsValueEqual(v, currentValue.Field(i).Interface().(attr.Value)) {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// accountSettingsKeepListOrder keeps the planned order of lists holding the same elements as in data.
+func accountSettingsKeepListOrder(planned AccountSettingsModel, data *AccountSettingsModel) {
+	plannedValue, dataValue := reflect.ValueOf(planned), reflect.ValueOf(data).Elem()
+	for i := range dataValue.NumField() {
+		v, ok := plannedValue.Field(i).Interface().(types.List)
+		if ok && !v.IsNull() && !v.IsUnknown() && accountSettingsValueEqual(v, dataValue.Field(i).Interface().(attr.Value)) {
+			dataValue.Field(i).Set(plannedValue.Field(i))
+		}
+	}
+}
+
+// accountSettingsValueEqual reports whether a and b are equal, lists are equal when they hold the same elements in any order.
+func accountSettingsValueEqual(a, b attr.Value) bool {
+	la, ok := a.(types.List)
+	lb, okb := b.(types.List)
+	if !ok || !okb || la.IsNull() || lb.IsNull() || la.IsUnknown() || lb.IsUnknown() {
+		return a.Equal(b)
+	}
+	ea, eb := la.Elements(), lb.Elements()
+	if len(ea) != len(eb) {
+		return false
+	}
+	for _, e := range ea {
+		if !slices.ContainsFunc(eb, e.Equal) {
+			return false
+		}
+	}
+	return true
+}
+
+// accountSettingsChanged reports whether applying req would change the current account settings.
+func accountSettingsChanged(ctx context.Context, account *api.Account, req api.AccountRequest) bool {
+	// Converting an empty model falls back to the current value for every setting
+	current := accountTerraformToAPI(ctx, account, AccountSettingsModel{})
+	return !reflect.DeepEqual(current, req)
+}
+
+// accountSettingsCloudOnlyWarnings warns about enabled cloud only settings when deployment is self-hosted.
+func accountSettingsCloudOnlyWarnings(deployment string, data AccountSettingsModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	if deployment != deploymentSelfHosted {
+		return ret
+	}
+	if data.PeerApprovalEnabled.ValueBool() {
+		ret.AddAttributeWarning(
+			path.Root("peer_approval_enabled"),
+			"Cloud Only Setting",
+			"peer_approval_enabled is only supported by NetBird Cloud and has no effect on self-hosted management servers.",
+		)
+	}
+	return ret
+}
+
+func (r *AccountSettings) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is applied on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data AccountSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(accountSettingsCloudOnlyWarnings(r.deployment, data.AccountSettingsModel)...)
+}
+
+// accountSettingsApplyPeerInactivity sets inactivity_expiration_enabled on peers added with SSO login that do not already match enabled.
+func accountSettingsApplyPeerInactivity(ctx context.Context, client *netbird.Client, enabled bool) diag.Diagnostics {
+	var ret diag.Diagnostics
+	peers, err := client.Peers.List(ctx)
+	if err != nil {
+		ret.AddError("Error listing Peers", formatAPIError(err))
+		return ret
+	}
+
+	for _, p := range peers {
+		// Inactivity expiration is only supported on peers added with SSO login
+		if p.UserId == "" || p.InactivityExpirationEnabled == enabled {
+			continue
+		}
+		_, err = client.Peers.Update(ctx, p.Id, api.PeerRequest{
+			InactivityExpirationEnabled: enabled,
+			LoginExpirationEnabled:      p.LoginExpirationEnabled,
+			Name:                        p.Name,
+			SshEnabled:                  p.SshEnabled,
+		})
+		if err != nil {
+			ret.AddError("Error updating Peer", fmt.Sprintf("Peer %s: %s", p.Id, formatAPIError(err)))
+		}
+	}
+	return ret
+}
+
 func (r *AccountSettings) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data AccountSettingsModel
+	var data AccountSettingsResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -305,21 +501,47 @@ func (r *AccountSettings) Create(ctx context.Context, req resource.CreateRequest
 
 	accounts, err := r.client.Accounts.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error getting AccountSettings", err.Error())
+		resp.Diagnostics.AddError("Error getting AccountSettings", formatAPIError(err))
 		return
 	}
 
-	account := &accounts[0]
+	account, d := firstAccount(accounts)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	updateRequest := accountTerraformToAPI(ctx, account, data)
+	planned := data.AccountSettingsModel
+	updateRequest := accountTerraformToAPI(ctx, account, data.AccountSettingsModel)
+	if data.AdoptOnly.ValueBool() {
+		var current AccountSettingsModel
+		resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &current)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Adopting never writes, configured settings must already match the account
+		if differing := accountSettingsDiffering(data.AccountSettingsModel, current); len(differing) > 0 {
+			resp.Diagnostics.AddError("Account Settings Differ", fmt.Sprintf("adopt_only does not write account settings, but the configured %s differ from the current settings of account %q. Align the configuration with the account, or disable adopt_only to write them.", strings.Join(differing, ", "), account.Id))
+			return
+		}
+	} else if accountSettingsChanged(ctx, account, updateRequest) {
+		account, err = r.client.Accounts.Update(ctx, account.Id, updateRequest)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating AccountSettings", formatAPIError(err))
+			return
+		}
+	}
 
-	account, err = r.client.Accounts.Update(ctx, account.Id, updateRequest)
-	if err != nil {
-		resp.Diagnostics.AddError("Error updating AccountSettings", err.Error())
-		return
+	if data.ApplyToExistingPeers.ValueBool() {
+		resp.Diagnostics.Append(accountSettingsApplyPeerInactivity(ctx, r.client, account.Settings.PeerInactivityExpirationEnabled)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &data)...)
+	resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &data.AccountSettingsModel)...)
+	// Adopted lists are not written when they only differ in order
+	accountSettingsKeepListOrder(planned, &data.AccountSettingsModel)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -330,7 +552,7 @@ func (r *AccountSettings) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *AccountSettings) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data AccountSettingsModel
+	var data AccountSettingsResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -340,19 +562,23 @@ func (r *AccountSettings) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	accounts, err := r.client.Accounts.List(ctx)
-	account := &accounts[0]
-
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting AccountSettings", err.Error())
+			resp.Diagnostics.AddError("Error getting AccountSettings", formatAPIError(err))
 		}
 		return
 	}
 
-	resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &data)...)
+	account, d := firstAccount(accounts)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &data.AccountSettingsModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -363,7 +589,7 @@ func (r *AccountSettings) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *AccountSettings) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AccountSettingsModel
+	var data AccountSettingsResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -379,21 +605,33 @@ func (r *AccountSettings) Update(ctx context.Context, req resource.UpdateRequest
 
 	accounts, err := r.client.Accounts.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error getting AccountSettings", err.Error())
+		resp.Diagnostics.AddError("Error getting AccountSettings", formatAPIError(err))
+		return
+	}
+	account, d := firstAccount(accounts)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	account := &accounts[0]
 
-	updateRequest := accountTerraformToAPI(ctx, account, data)
+	updateRequest := accountTerraformToAPI(ctx, account, data.AccountSettingsModel)
 
-	account, err = r.client.Accounts.Update(ctx, data.Id.ValueString(), updateRequest)
+	if accountSettingsChanged(ctx, account, updateRequest) {
+		account, err = r.client.Accounts.Update(ctx, data.Id.ValueString(), updateRequest)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating AccountSettings", formatAPIError(err))
+			return
+		}
+	}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error updating AccountSettings", err.Error())
-		return
+	if data.ApplyToExistingPeers.ValueBool() {
+		resp.Diagnostics.Append(accountSettingsApplyPeerInactivity(ctx, r.client, account.Settings.PeerInactivityExpirationEnabled)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &data)...)
+	resp.Diagnostics.Append(accountAPIToTerraform(ctx, account, &data.AccountSettingsModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -406,5 +644,23 @@ func (r *AccountSettings) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *AccountSettings) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" && req.ID != "account" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	// A token only has access to a single account, resolve it instead of requiring its ID
+	accounts, err := r.client.Accounts.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error getting AccountSettings", formatAPIError(err))
+		return
+	}
+
+	account, d := firstAccount(accounts)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), account.Id)...)
 }
@@ -2,12 +2,20 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/netbirdio/netbird/shared/management/http/api"
@@ -300,6 +308,481 @@ func Test_accountTerraformToAPI(t *testing.T) {
 	}
 }
 
+func Test_AccountSettings_Create_skipUnchanged(t *testing.T) {
+	account := api.Account{
+		Id: "a1",
+		Settings: api.AccountSettings{
+			PeerInactivityExpiration: 1800,
+			PeerLoginExpiration:      86400,
+			JwtAllowGroups:           &[]string{},
+			Extra:                    &api.AccountExtraSettings{NetworkTrafficLogsGroups: []string{}},
+			PeerExposeGroups:         []string{},
+		},
+	}
+
+	cases := []struct {
+		peerLoginExpiration int32
+		expectedUpdates     int
+	}{
+		{
+			peerLoginExpiration: 86400,
+			expectedUpdates:     0,
+		},
+		{
+			peerLoginExpiration: 3600,
+			expectedUpdates:     1,
+		},
+	}
+
+	for _, c := range cases {
+		updates := 0
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPut {
+				updates++
+				_ = json.NewEncoder(w).Encode(account)
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]api.Account{account})
+		})
+
+		var data AccountSettingsResourceModel
+		diags := accountAPIToTerraform(context.Background(), &account, &data.AccountSettingsModel)
+		if diags.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+		}
+		data.Id = types.StringUnknown()
+		data.PeerLoginExpiration = types.Int32Value(c.peerLoginExpiration)
+
+		r := &AccountSettings{client: client}
+		plan := testResourceState(t, r, &data)
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+		r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		if updates != c.expectedUpdates {
+			t.Fatalf("Expected %d account updates, found %d", c.expectedUpdates, updates)
+		}
+	}
+}
+
+func Test_AccountSettings_Create_adoptOnly(t *testing.T) {
+	account := api.Account{
+		Id: "a1",
+		Settings: api.AccountSettings{
+			PeerLoginExpiration: 86400,
+			JwtAllowGroups:      &[]string{"g1", "g2"},
+			Extra:               &api.AccountExtraSettings{NetworkTrafficLogsGroups: []string{}},
+			PeerExposeGroups:    []string{},
+		},
+	}
+
+	cases := []struct {
+		adoptOnly           bool
+		peerLoginExpiration int32
+		jwtAllowGroups      []string
+		expectedUpdates     int
+		expectedGroups      []string
+		expectedError       string
+	}{
+		{adoptOnly: true, peerLoginExpiration: 86400, jwtAllowGroups: []string{"g1", "g2"}, expectedUpdates: 0},
+		{adoptOnly: true, peerLoginExpiration: 86400, jwtAllowGroups: []string{"g2", "g1"}, expectedUpdates: 0},
+		{adoptOnly: true, peerLoginExpiration: 3600, jwtAllowGroups: []string{"g2", "g1"}, expectedUpdates: 0, expectedError: "peer_login_expiration"},
+		{adoptOnly: true, peerLoginExpiration: 86400, jwtAllowGroups: []string{"g1", "g3"}, expectedUpdates: 0, expectedError: "jwt_allow_groups"},
+		{adoptOnly: false, peerLoginExpiration: 3600, jwtAllowGroups: []string{"g2", "g1"}, expectedUpdates: 1, expectedGroups: []string{"g2", "g1"}},
+	}
+
+	for _, c := range cases {
+		var updates []api.AccountRequest
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPut {
+				var req api.AccountRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatalf("Failed to decode request: %v", err)
+				}
+				updates = append(updates, req)
+				_ = json.NewEncoder(w).Encode(api.Account{Id: account.Id, Settings: req.Settings})
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]api.Account{account})
+		})
+
+		// Only peer_login_expiration and jwt_allow_groups are configured, the other settings are unknown as in a plan
+		data := AccountSettingsResourceModel{
+			AccountSettingsModel: AccountSettingsModel{
+				Id:                       types.StringUnknown(),
+				JwtAllowGroups:           types.ListValueMust(types.StringType, []attr.Value{types.StringValue(c.jwtAllowGroups[0]), types.StringValue(c.jwtAllowGroups[1])}),
+				PeerLoginExpiration:      types.Int32Value(c.peerLoginExpiration),
+				NetworkTrafficLogsGroups: types.ListUnknown(types.StringType),
+				PeerExposeGroups:         types.ListUnknown(types.StringType),
+			},
+			AdoptOnly: types.BoolValue(c.adoptOnly),
+		}
+
+		r := &AccountSettings{client: client}
+		plan := testResourceState(t, r, &data)
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+		r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+		if len(updates) != c.expectedUpdates {
+			t.Fatalf("Expected %d account updates with adopt_only %t, found %d", c.expectedUpdates, c.adoptOnly, len(updates))
+		}
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Account Settings Differ" || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), c.expectedError) {
+				t.Fatalf("Expected differing %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		if len(updates) > 0 {
+			if updates[0].Settings.PeerLoginExpiration != int(c.peerLoginExpiration) {
+				t.Fatalf("Expected peer_login_expiration %d to be written, found %d", c.peerLoginExpiration, updates[0].Settings.PeerLoginExpiration)
+			}
+			if !reflect.DeepEqual(*updates[0].Settings.JwtAllowGroups, c.expectedGroups) {
+				t.Fatalf("Expected jwt_allow_groups %v to be written with adopt_only %t, found %v", c.expectedGroups, c.adoptOnly, *updates[0].Settings.JwtAllowGroups)
+			}
+		}
+
+		var out AccountSettingsResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		if !out.JwtAllowGroups.Equal(data.JwtAllowGroups) {
+			t.Fatalf("Expected planned jwt_allow_groups %s in state, found %s", data.JwtAllowGroups, out.JwtAllowGroups)
+		}
+	}
+}
+
+func Test_AccountSettings_ModifyPlan_cloudOnly(t *testing.T) {
+	account := api.Account{
+		Id: "a1",
+		Settings: api.AccountSettings{
+			JwtAllowGroups:   &[]string{},
+			Extra:            &api.AccountExtraSettings{PeerApprovalEnabled: true, NetworkTrafficLogsGroups: []string{}},
+			PeerExposeGroups: []string{},
+		},
+	}
+
+	cases := []struct {
+		deployment          string
+		peerApprovalEnabled bool
+		expectedWarnings    int
+	}{
+		{deployment: deploymentSelfHosted, peerApprovalEnabled: true, expectedWarnings: 1},
+		{deployment: deploymentSelfHosted, peerApprovalEnabled: false, expectedWarnings: 0},
+		{deployment: deploymentCloud, peerApprovalEnabled: true, expectedWarnings: 0},
+	}
+
+	for _, c := range cases {
+		account.Settings.Extra.PeerApprovalEnabled = c.peerApprovalEnabled
+		var data AccountSettingsResourceModel
+		diags := accountAPIToTerraform(context.Background(), &account, &data.AccountSettingsModel)
+		if diags.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+		}
+		data.Id = types.StringUnknown()
+
+		r := &AccountSettings{deployment: c.deployment}
+		state := testResourceState(t, r, &data)
+		plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+		resp := tfresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(context.Background(), tfresource.ModifyPlanRequest{Plan: plan}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if resp.Diagnostics.WarningsCount() != c.expectedWarnings {
+			t.Fatalf("Expected %d warnings with peer_approval_enabled %t on %s, found %v", c.expectedWarnings, c.peerApprovalEnabled, c.deployment, resp.Diagnostics.Warnings())
+		}
+		if c.expectedWarnings > 0 && resp.Diagnostics.Warnings()[0].Summary() != "Cloud Only Setting" {
+			t.Fatalf("Expected Cloud Only Setting warning, found %s", resp.Diagnostics.Warnings()[0].Summary())
+		}
+	}
+}
+
+func Test_AccountSettings_Create_applyToExistingPeers(t *testing.T) {
+	account := api.Account{
+		Id: "a1",
+		Settings: api.AccountSettings{
+			JwtAllowGroups:                  &[]string{},
+			Extra:                           &api.AccountExtraSettings{NetworkTrafficLogsGroups: []string{}},
+			PeerExposeGroups:                []string{},
+			PeerInactivityExpirationEnabled: true,
+		},
+	}
+	peers := []api.Peer{
+		{Id: "p1", Name: "laptop", UserId: "u1", InactivityExpirationEnabled: false, LoginExpirationEnabled: true},
+		{Id: "p2", Name: "desktop", UserId: "u2", InactivityExpirationEnabled: true},
+		{Id: "p3", Name: "server", InactivityExpirationEnabled: false},
+	}
+
+	for _, apply := range []bool{false, true} {
+		updated := map[string]api.PeerRequest{}
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/api/accounts":
+				_ = json.NewEncoder(w).Encode([]api.Account{account})
+			case r.URL.Path == "/api/peers":
+				_ = json.NewEncoder(w).Encode(peers)
+			case r.Method == http.MethodPut:
+				var req api.PeerRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				updated[r.URL.Path] = req
+				_ = json.NewEncoder(w).Encode(api.Peer{})
+			default:
+				t.Fatalf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		})
+
+		var data AccountSettingsResourceModel
+		diags := accountAPIToTerraform(context.Background(), &account, &data.AccountSettingsModel)
+		if diags.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+		}
+		data.Id = types.StringUnknown()
+		data.ApplyToExistingPeers = types.BoolValue(apply)
+
+		r := &AccountSettings{client: client}
+		plan := testResourceState(t, r, &data)
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+		r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		expected := map[string]api.PeerRequest{}
+		if apply {
+			expected["/api/peers/p1"] = api.PeerRequest{Name: "laptop", InactivityExpirationEnabled: true, LoginExpirationEnabled: true}
+		}
+		if !reflect.DeepEqual(updated, expected) {
+			t.Fatalf("Expected peer updates %v with apply_to_existing_peers %t, found %v", expected, apply, updated)
+		}
+
+		var out AccountSettingsResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		if !out.ApplyToExistingPeers.Equal(types.BoolValue(apply)) {
+			t.Fatalf("Expected apply_to_existing_peers %t in state, found %s", apply, out.ApplyToExistingPeers)
+		}
+	}
+}
+
+func Test_accountSettingsConfigValidator(t *testing.T) {
+	cases := []struct {
+		name                            string
+		peerLoginExpiration             types.Int32
+		peerLoginExpirationEnabled      types.Bool
+		peerInactivityExpiration        types.Int32
+		peerInactivityExpirationEnabled types.Bool
+		expectedWarnings                []string
+	}{
+		{
+			name:                            "login expiration disabled with value",
+			peerLoginExpiration:             types.Int32Value(86400),
+			peerLoginExpirationEnabled:      types.BoolValue(false),
+			peerInactivityExpiration:        types.Int32Null(),
+			peerInactivityExpirationEnabled: types.BoolNull(),
+			expectedWarnings:                []string{"peer_login_expiration"},
+		},
+		{
+			name:                            "inactivity expiration disabled with value",
+			peerLoginExpiration:             types.Int32Null(),
+			peerLoginExpirationEnabled:      types.BoolNull(),
+			peerInactivityExpiration:        types.Int32Value(3600),
+			peerInactivityExpirationEnabled: types.BoolValue(false),
+			expectedWarnings:                []string{"peer_inactivity_expiration"},
+		},
+		{
+			name:                            "enabled with values",
+			peerLoginExpiration:             types.Int32Value(86400),
+			peerLoginExpirationEnabled:      types.BoolValue(true),
+			peerInactivityExpiration:        types.Int32Value(3600),
+			peerInactivityExpirationEnabled: types.BoolValue(true),
+		},
+		{
+			name:                            "disabled without values",
+			peerLoginExpiration:             types.Int32Null(),
+			peerLoginExpirationEnabled:      types.BoolValue(false),
+			peerInactivityExpiration:        types.Int32Null(),
+			peerInactivityExpirationEnabled: types.BoolValue(false),
+		},
+		{
+			name:                            "values without enabled",
+			peerLoginExpiration:             types.Int32Value(86400),
+			peerLoginExpirationEnabled:      types.BoolNull(),
+			peerInactivityExpiration:        types.Int32Value(3600),
+			peerInactivityExpirationEnabled: types.BoolUnknown(),
+		},
+	}
+
+	r := &AccountSettings{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := testResourceState(t, r, &AccountSettingsResourceModel{AccountSettingsModel: AccountSettingsModel{
+				JwtAllowGroups:                  types.ListNull(types.StringType),
+				PeerLoginExpiration:             c.peerLoginExpiration,
+				PeerLoginExpirationEnabled:      c.peerLoginExpirationEnabled,
+				PeerInactivityExpiration:        c.peerInactivityExpiration,
+				PeerInactivityExpirationEnabled: c.peerInactivityExpirationEnabled,
+				NetworkTrafficLogsGroups:        types.ListNull(types.StringType),
+				PeerExposeGroups:                types.ListNull(types.StringType),
+			}})
+
+			resp := tfresource.ValidateConfigResponse{}
+			for _, v := range r.ConfigValidators(context.Background()) {
+				v.ValidateResource(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			var warnings []string
+			for _, w := range resp.Diagnostics.Warnings() {
+				warnings = append(warnings, w.(diag.DiagnosticWithPath).Path().String())
+			}
+			if !reflect.DeepEqual(warnings, c.expectedWarnings) {
+				t.Fatalf("Expected warnings for %v, found %v", c.expectedWarnings, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
+
+func Test_accountAPIToTerraform_missingExtra(t *testing.T) {
+	data := AccountSettingsModel{
+		Id:             types.StringValue("a1"),
+		JwtAllowGroups: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+	}
+	expected := data
+
+	diags := accountAPIToTerraform(context.Background(), &api.Account{Id: "a1", Settings: api.AccountSettings{JwtAllowGroups: &[]string{"g2"}}}, &data)
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Unexpected Value" {
+		t.Fatalf("Expected Unexpected Value error, found %v", diags.Errors())
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("Expected data to be left unchanged:\n%#v\nFound:\n%#v", expected, data)
+	}
+}
+
+func Test_AccountSettings_noAccounts(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	})
+
+	var data AccountSettingsResourceModel
+	diags := accountAPIToTerraform(context.Background(), &api.Account{Id: "a1", Settings: api.AccountSettings{Extra: &api.AccountExtraSettings{}}}, &data.AccountSettingsModel)
+	if diags.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+	}
+
+	r := &AccountSettings{client: client}
+	state := testResourceState(t, r, &data)
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+	createResp := tfresource.CreateResponse{State: tfsdk.State{Schema: state.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: plan}, &createResp)
+	readResp := tfresource.ReadResponse{State: state}
+	r.Read(context.Background(), tfresource.ReadRequest{State: state}, &readResp)
+	updateResp := tfresource.UpdateResponse{State: state}
+	r.Update(context.Background(), tfresource.UpdateRequest{Plan: plan, State: state}, &updateResp)
+
+	for name, d := range map[string]diag.Diagnostics{"Create": createResp.Diagnostics, "Read": readResp.Diagnostics, "Update": updateResp.Diagnostics} {
+		if d.ErrorsCount() != 1 || d.Errors()[0].Summary() != "No Account Found" {
+			t.Fatalf("Expected %s to fail with No Account Found, found %v", name, d.Errors())
+		}
+	}
+}
+
+func Test_AccountSettings_Read_listError(t *testing.T) {
+	cases := []struct {
+		status   int
+		message  string
+		removed  bool
+		errorMsg string
+	}{
+		{
+			status:   http.StatusInternalServerError,
+			message:  "internal error",
+			errorMsg: "Error getting AccountSettings",
+		},
+		{
+			status:  http.StatusNotFound,
+			message: "account not found",
+			removed: true,
+		},
+	}
+
+	for _, c := range cases {
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(c.status)
+			_ = json.NewEncoder(w).Encode(map[string]any{"message": c.message, "code": c.status})
+		})
+
+		var data AccountSettingsResourceModel
+		diags := accountAPIToTerraform(context.Background(), &api.Account{Id: "a1", Settings: api.AccountSettings{Extra: &api.AccountExtraSettings{}}}, &data.AccountSettingsModel)
+		if diags.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+		}
+
+		r := &AccountSettings{client: client}
+		state := testResourceState(t, r, &data)
+		readResp := tfresource.ReadResponse{State: state}
+		r.Read(context.Background(), tfresource.ReadRequest{State: state}, &readResp)
+		if c.removed {
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", readResp.Diagnostics.Errors())
+			}
+			if !readResp.State.Raw.IsNull() {
+				t.Fatalf("Expected resource to be removed from state")
+			}
+		} else if readResp.Diagnostics.ErrorsCount() != 1 || readResp.Diagnostics.Errors()[0].Summary() != c.errorMsg {
+			t.Fatalf("Expected resource Read to fail with %s, found %v", c.errorMsg, readResp.Diagnostics.Errors())
+		}
+
+		d := &AccountSettingsDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &data.AccountSettingsModel)
+		d.Read(context.Background(), req, resp)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Error getting AccountSettings" {
+			t.Fatalf("Expected data source Read to fail with Error getting AccountSettings, found %v", resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func Test_AccountSettings_ImportState(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]api.Account{{Id: "account1"}})
+	})
+
+	cases := []struct {
+		id       string
+		expected string
+	}{
+		{id: "account", expected: "account1"},
+		{id: "", expected: "account1"},
+		{id: "account2", expected: "account2"},
+	}
+
+	r := &AccountSettings{client: client}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		resp := tfresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}}
+		r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: c.id}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var id types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+		if id.ValueString() != c.expected {
+			t.Fatalf("Expected import of %q to set id %s, found %s", c.id, c.expected, id)
+		}
+	}
+}
+
 func Test_Account_Create(t *testing.T) {
 	rName := "acc" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 	rNameFull := "netbird_account_settings." + rName
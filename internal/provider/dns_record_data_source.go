@@ -72,18 +72,18 @@ func (d *DNSRecordDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *DNSRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -99,7 +99,7 @@ func (d *DNSRecordDataSource) Read(ctx context.Context, req datasource.ReadReque
 	var record *api.DNSRecord
 	records, err := d.client.DNSZones.ListRecords(ctx, data.ZoneId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing DNS Records", err.Error())
+		resp.Diagnostics.AddError("Error listing DNS Records", formatAPIError(err))
 		return
 	}
 
@@ -103,18 +103,18 @@ func (r *DNSRecord) Configure(ctx context.Context, req resource.ConfigureRequest
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func dnsRecordAPIToTerraform(record *api.DNSRecord, zoneId string, data *DNSRecordModel) {
@@ -145,7 +145,7 @@ func (r *DNSRecord) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	record, err := r.client.DNSZones.CreateRecord(ctx, data.ZoneId.ValueString(), recordReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating DNS record", err.Error())
+		resp.Diagnostics.AddError("Error creating DNS record", formatAPIError(err))
 		return
 	}
 
@@ -171,7 +171,7 @@ func (r *DNSRecord) Read(ctx context.Context, req resource.ReadRequest, resp *re
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting DNS Record", err.Error())
+			resp.Diagnostics.AddError("Error getting DNS Record", formatAPIError(err))
 		}
 		return
 	}
@@ -206,7 +206,7 @@ func (r *DNSRecord) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	record, err := r.client.DNSZones.UpdateRecord(ctx, data.ZoneId.ValueString(), data.Id.ValueString(), recordReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating DNS Record", err.Error())
+		resp.Diagnostics.AddError("Error updating DNS Record", formatAPIError(err))
 		return
 	}
 
@@ -227,8 +227,8 @@ func (r *DNSRecord) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	}
 
 	err := r.client.DNSZones.DeleteRecord(ctx, data.ZoneId.ValueString(), data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting DNS Record", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting DNS Record", formatAPIError(err))
 	}
 }
 
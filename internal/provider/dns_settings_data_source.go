@@ -48,18 +48,18 @@ func (d *DNSSettingsDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *DNSSettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -75,7 +75,7 @@ func (d *DNSSettingsDataSource) Read(ctx context.Context, req datasource.ReadReq
 	dnsSettings, err := d.client.DNS.GetSettings(ctx)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error getting DNSSettings", err.Error())
+		resp.Diagnostics.AddError("Error getting DNSSettings", formatAPIError(err))
 		return
 	}
 
@@ -67,18 +67,18 @@ func (r *DNSSettings) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func dnsSettingsAPIToTerraform(ctx context.Context, dnsSettings *api.DNSSettings, data *DNSSettingsModel) diag.Diagnostics {
@@ -101,7 +101,7 @@ func (r *DNSSettings) Create(ctx context.Context, req resource.CreateRequest, re
 
 	dnsSettings, err := r.client.DNS.GetSettings(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error getting DNSSettings", err.Error())
+		resp.Diagnostics.AddError("Error getting DNSSettings", formatAPIError(err))
 		return
 	}
 
@@ -111,7 +111,7 @@ func (r *DNSSettings) Create(ctx context.Context, req resource.CreateRequest, re
 
 	dnsSettings, err = r.client.DNS.UpdateSettings(ctx, updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating DNSSettings", err.Error())
+		resp.Diagnostics.AddError("Error updating DNSSettings", formatAPIError(err))
 		return
 	}
 
@@ -138,7 +138,7 @@ func (r *DNSSettings) Read(ctx context.Context, req resource.ReadRequest, resp *
 	dnsSettings, err := r.client.DNS.GetSettings(ctx)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error getting DNSSettings", err.Error())
+		resp.Diagnostics.AddError("Error getting DNSSettings", formatAPIError(err))
 		return
 	}
 
@@ -167,7 +167,7 @@ func (r *DNSSettings) Update(ctx context.Context, req resource.UpdateRequest, re
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating DNSSettings", err.Error())
+		resp.Diagnostics.AddError("Error updating DNSSettings", formatAPIError(err))
 		return
 	}
 
@@ -74,18 +74,18 @@ func (d *DNSZoneDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *DNSZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -101,7 +101,7 @@ func (d *DNSZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	var zone *api.Zone
 	zones, err := d.client.DNSZones.ListZones(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing DNS Zones", err.Error())
+		resp.Diagnostics.AddError("Error listing DNS Zones", formatAPIError(err))
 		return
 	}
 
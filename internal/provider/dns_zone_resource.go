@@ -104,18 +104,18 @@ func (r *DNSZone) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func dnsZoneAPIToTerraform(ctx context.Context, zone *api.Zone, data *DNSZoneModel) diag.Diagnostics {
@@ -154,7 +154,7 @@ func (r *DNSZone) Create(ctx context.Context, req resource.CreateRequest, resp *
 
 	zone, err := r.client.DNSZones.CreateZone(ctx, zoneReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating DNS zone", err.Error())
+		resp.Diagnostics.AddError("Error creating DNS zone", formatAPIError(err))
 		return
 	}
 
@@ -184,7 +184,7 @@ func (r *DNSZone) Read(ctx context.Context, req resource.ReadRequest, resp *reso
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting DNS Zone", err.Error())
+			resp.Diagnostics.AddError("Error getting DNS Zone", formatAPIError(err))
 		}
 		return
 	}
@@ -225,7 +225,7 @@ func (r *DNSZone) Update(ctx context.Context, req resource.UpdateRequest, resp *
 
 	zone, err := r.client.DNSZones.UpdateZone(ctx, data.Id.ValueString(), zoneReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating DNS Zone", err.Error())
+		resp.Diagnostics.AddError("Error updating DNS Zone", formatAPIError(err))
 		return
 	}
 
@@ -249,8 +249,8 @@ func (r *DNSZone) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 	}
 
 	err := r.client.DNSZones.DeleteZone(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting DNS Zone", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting DNS Zone", formatAPIError(err))
 	}
 }
 
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EventsDataSource{}
+
+func NewEventsDataSource() datasource.DataSource {
+	return &EventsDataSource{}
+}
+
+// EventsDataSource defines the data source implementation.
+type EventsDataSource struct {
+	client *netbird.Client
+}
+
+// EventsModel describes the data source data model.
+type EventsModel struct {
+	Since        types.String `tfsdk:"since"`
+	Limit        types.Int32  `tfsdk:"limit"`
+	ActivityCode types.String `tfsdk:"activity_code"`
+	Events       types.List   `tfsdk:"events"`
+}
+
+// EventModel describes a single audit event.
+type EventModel struct {
+	Id             types.String `tfsdk:"id"`
+	Timestamp      types.String `tfsdk:"timestamp"`
+	Activity       types.String `tfsdk:"activity"`
+	ActivityCode   types.String `tfsdk:"activity_code"`
+	InitiatorId    types.String `tfsdk:"initiator_id"`
+	InitiatorName  types.String `tfsdk:"initiator_name"`
+	InitiatorEmail types.String `tfsdk:"initiator_email"`
+	TargetId       types.String `tfsdk:"target_id"`
+	Meta           types.Map    `tfsdk:"meta"`
+}
+
+// TFType returns the Terraform object type for events.
+func (m EventModel) TFType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":              types.StringType,
+			"timestamp":       types.StringType,
+			"activity":        types.StringType,
+			"activity_code":   types.StringType,
+			"initiator_id":    types.StringType,
+			"initiator_name":  types.StringType,
+			"initiator_email": types.StringType,
+			"target_id":       types.StringType,
+			"meta":            types.MapType{ElemType: types.StringType},
+		},
+	}
+}
+
+func (d *EventsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_events"
+}
+
+func (d *EventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Read account audit events",
+		MarkdownDescription: "Read account audit events, see [NetBird Docs](https://docs.netbird.io/how-to/audit-events-logging) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"since": schema.StringAttribute{
+				MarkdownDescription: "Only return events at or after this RFC3339 timestamp",
+				Optional:            true,
+			},
+			"limit": schema.Int32Attribute{
+				MarkdownDescription: "Maximum number of events to return, the most recent events are kept",
+				Optional:            true,
+				Validators:          []validator.Int32{int32validator.AtLeast(1)},
+			},
+			"activity_code": schema.StringAttribute{
+				MarkdownDescription: "Only return events with this activity code, e.g. `peer.user.add`",
+				Optional:            true,
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "Audit events, most recent first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Event ID",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "Time the event occurred",
+							Computed:            true,
+						},
+						"activity": schema.StringAttribute{
+							MarkdownDescription: "Description of the activity",
+							Computed:            true,
+						},
+						"activity_code": schema.StringAttribute{
+							MarkdownDescription: "Activity code",
+							Computed:            true,
+						},
+						"initiator_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the user or service that triggered the event",
+							Computed:            true,
+						},
+						"initiator_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the initiator",
+							Computed:            true,
+						},
+						"initiator_email": schema.StringAttribute{
+							MarkdownDescription: "E-mail address of the initiator",
+							Computed:            true,
+						},
+						"target_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the object the activity was performed on",
+							Computed:            true,
+						},
+						"meta": schema.MapAttribute{
+							MarkdownDescription: "Event metadata",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EventsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// filterEvents returns events matching since and activityCode, most recent first and at most limit events if limit is positive.
+func filterEvents(events []api.Event, since time.Time, activityCode string, limit int) []api.Event {
+	ret := []api.Event{}
+	for _, e := range events {
+		if e.Timestamp.Before(since) || (activityCode != "" && string(e.ActivityCode) != activityCode) {
+			continue
+		}
+		ret = append(ret, e)
+	}
+	slices.SortStableFunc(ret, func(a, b api.Event) int {
+		return b.Timestamp.Compare(a.Timestamp)
+	})
+	if limit > 0 && len(ret) > limit {
+		ret = ret[:limit]
+	}
+	return ret
+}
+
+func (d *EventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EventsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var since time.Time
+	if !data.Since.IsNull() {
+		var err error
+		since, err = time.Parse(time.RFC3339, data.Since.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("since"), "Invalid Timestamp", fmt.Sprintf("since must be an RFC3339 timestamp, %s", err.Error()))
+			return
+		}
+	}
+
+	events, err := d.client.Events.ListAuditEvents(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Events", formatAPIError(err))
+		return
+	}
+
+	events = filterEvents(events, since, data.ActivityCode.ValueString(), int(data.Limit.ValueInt32()))
+	eventModels := make([]EventModel, len(events))
+	for i, e := range events {
+		meta, di := types.MapValueFrom(ctx, types.StringType, e.Meta)
+		resp.Diagnostics.Append(di...)
+		eventModels[i] = EventModel{
+			Id:             types.StringValue(e.Id),
+			Timestamp:      types.StringValue(e.Timestamp.Format(time.RFC3339)),
+			Activity:       types.StringValue(e.Activity),
+			ActivityCode:   types.StringValue(string(e.ActivityCode)),
+			InitiatorId:    types.StringValue(e.InitiatorId),
+			InitiatorName:  types.StringValue(e.InitiatorName),
+			InitiatorEmail: types.StringValue(e.InitiatorEmail),
+			TargetId:       types.StringValue(e.TargetId),
+			Meta:           meta,
+		}
+	}
+
+	l, di := types.ListValueFrom(ctx, EventModel{}.TFType(), eventModels)
+	resp.Diagnostics.Append(di...)
+	data.Events = l
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
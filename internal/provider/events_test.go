@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_EventsDataSource_Read(t *testing.T) {
+	cases := []struct {
+		since         types.String
+		limit         types.Int32
+		activityCode  types.String
+		expectedIds   []string
+		expectedError string
+	}{
+		{since: types.StringNull(), limit: types.Int32Null(), activityCode: types.StringNull(), expectedIds: []string{"e4", "e3", "e2", "e1"}},
+		{since: types.StringNull(), limit: types.Int32Null(), activityCode: types.StringValue("peer.user.add"), expectedIds: []string{"e3", "e1"}},
+		{since: types.StringValue("2025-01-02T00:00:00Z"), limit: types.Int32Null(), activityCode: types.StringValue("peer.user.add"), expectedIds: []string{"e3"}},
+		{since: types.StringNull(), limit: types.Int32Value(2), activityCode: types.StringNull(), expectedIds: []string{"e4", "e3"}},
+		{since: types.StringNull(), limit: types.Int32Null(), activityCode: types.StringValue("user.invite"), expectedIds: []string{}},
+		{since: types.StringValue("yesterday"), limit: types.Int32Null(), activityCode: types.StringNull(), expectedError: "Invalid Timestamp"},
+	}
+
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id":"e1","timestamp":"2025-01-01T10:00:00Z","activity":"Peer added","activity_code":"peer.user.add","initiator_id":"u1","target_id":"p1","meta":{}},
+			{"id":"e2","timestamp":"2025-01-02T10:00:00Z","activity":"Group created","activity_code":"group.add","initiator_id":"u1","target_id":"g1","meta":{"name":"devs"}},
+			{"id":"e4","timestamp":"2025-01-04T10:00:00Z","activity":"Route created","activity_code":"route.add","initiator_id":"u2","target_id":"r1","meta":{}},
+			{"id":"e3","timestamp":"2025-01-03T10:00:00Z","activity":"Peer added","activity_code":"peer.user.add","initiator_id":"u2","target_id":"p2","meta":{}}
+		]`))
+	})
+
+	for _, c := range cases {
+		d := &EventsDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &EventsModel{
+			Since:        c.since,
+			Limit:        c.limit,
+			ActivityCode: c.activityCode,
+			Events:       types.ListNull(EventModel{}.TFType()),
+		})
+		d.Read(context.Background(), req, resp)
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out EventsModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		var events []EventModel
+		resp.Diagnostics.Append(out.Events.ElementsAs(context.Background(), &events, false)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		ids := []string{}
+		for _, e := range events {
+			ids = append(ids, e.Id.ValueString())
+		}
+		if !reflect.DeepEqual(ids, c.expectedIds) {
+			t.Fatalf("Expected events %v for activity_code %s, found %v", c.expectedIds, c.activityCode, ids)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GeoLocationsDataSource{}
+
+func NewGeoLocationsDataSource() datasource.DataSource {
+	return &GeoLocationsDataSource{}
+}
+
+// GeoLocationsDataSource defines the data source implementation.
+type GeoLocationsDataSource struct {
+	client *netbird.Client
+}
+
+// GeoLocationsModel describes the data source data model.
+type GeoLocationsModel struct {
+	CountryCode types.String `tfsdk:"country_code"`
+	Countries   types.List   `tfsdk:"countries"`
+	Cities      types.List   `tfsdk:"cities"`
+}
+
+// GeoCountryModel describes a country known to the management server.
+type GeoCountryModel struct {
+	CountryCode types.String `tfsdk:"country_code"`
+	CountryName types.String `tfsdk:"country_name"`
+}
+
+// TFType returns the Terraform object type for countries.
+func (m GeoCountryModel) TFType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"country_code": types.StringType,
+			"country_name": types.StringType,
+		},
+	}
+}
+
+// GeoCityModel describes a city known to the management server.
+type GeoCityModel struct {
+	CityName  types.String `tfsdk:"city_name"`
+	GeonameId types.Int32  `tfsdk:"geoname_id"`
+}
+
+// TFType returns the Terraform object type for cities.
+func (m GeoCityModel) TFType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"city_name":  types.StringType,
+			"geoname_id": types.Int32Type,
+		},
+	}
+}
+
+func (d *GeoLocationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_geo_locations"
+}
+
+func (d *GeoLocationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Read Countries and Cities known to the Geo Location database",
+		MarkdownDescription: "Read Countries and Cities known to the Geo Location database, for use in posture check `geo_location_check` blocks, see [NetBird Docs](https://docs.netbird.io/how-to/manage-posture-checks#geo-location-check) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"country_code": schema.StringAttribute{
+				MarkdownDescription: "2-letter ISO 3166-1 alpha-2 country code, limits countries to this country and lists its cities",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Z]{2}$`), "must be a 2-letter uppercase ISO 3166-1 alpha-2 country code")},
+			},
+			"countries": schema.ListNestedAttribute{
+				MarkdownDescription: "Countries, ordered as returned by the API",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"country_code": schema.StringAttribute{
+							MarkdownDescription: "2-letter ISO 3166-1 alpha-2 country code",
+							Computed:            true,
+						},
+						"country_name": schema.StringAttribute{
+							MarkdownDescription: "Country name",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"cities": schema.ListNestedAttribute{
+				MarkdownDescription: "Cities of the country, null if country_code is not set",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"city_name": schema.StringAttribute{
+							MarkdownDescription: "City name",
+							Computed:            true,
+						},
+						"geoname_id": schema.Int32Attribute{
+							MarkdownDescription: "City ID in the GeoNames database",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *GeoLocationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *GeoLocationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GeoLocationsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	countries, err := d.client.GeoLocation.ListCountries(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Countries", formatAPIError(err))
+		return
+	}
+
+	countryModels := []GeoCountryModel{}
+	for _, c := range countries {
+		if !data.CountryCode.IsNull() && string(c.CountryCode) != data.CountryCode.ValueString() {
+			continue
+		}
+		countryModels = append(countryModels, GeoCountryModel{
+			CountryCode: types.StringValue(string(c.CountryCode)),
+			CountryName: types.StringValue(c.CountryName),
+		})
+	}
+
+	data.Cities = types.ListNull(GeoCityModel{}.TFType())
+	if !data.CountryCode.IsNull() {
+		if len(countryModels) == 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("country_code"), "Country Not Found", fmt.Sprintf("Country %q is not known to the Geo Location database", data.CountryCode.ValueString()))
+			return
+		}
+
+		cities, err := d.client.GeoLocation.ListCountryCities(ctx, data.CountryCode.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing Cities", formatAPIError(err))
+			return
+		}
+		cityModels := make([]GeoCityModel, len(cities))
+		for i, c := range cities {
+			cityModels[i] = GeoCityModel{
+				CityName:  types.StringValue(c.CityName),
+				GeonameId: types.Int32Value(int32(c.GeonameId)),
+			}
+		}
+		l, di := types.ListValueFrom(ctx, GeoCityModel{}.TFType(), cityModels)
+		resp.Diagnostics.Append(di...)
+		data.Cities = l
+	}
+
+	l, di := types.ListValueFrom(ctx, GeoCountryModel{}.TFType(), countryModels)
+	resp.Diagnostics.Append(di...)
+	data.Countries = l
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_GeoLocationsDataSource_Read(t *testing.T) {
+	cases := []struct {
+		countryCode       types.String
+		expectedCountries []string
+		expectedCities    []string
+		expectedError     string
+	}{
+		{countryCode: types.StringNull(), expectedCountries: []string{"DE", "FR"}},
+		{countryCode: types.StringValue("DE"), expectedCountries: []string{"DE"}, expectedCities: []string{"Berlin", "Hamburg"}},
+		{countryCode: types.StringValue("XX"), expectedError: "Country Not Found"},
+	}
+
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/locations/countries":
+			_, _ = w.Write([]byte(`[{"country_code":"DE","country_name":"Germany"},{"country_code":"FR","country_name":"France"}]`))
+		case "/api/locations/countries/DE/cities":
+			_, _ = w.Write([]byte(`[{"city_name":"Berlin","geoname_id":2950159},{"city_name":"Hamburg","geoname_id":2911298}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found","code":404}`))
+		}
+	})
+
+	for _, c := range cases {
+		d := &GeoLocationsDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &GeoLocationsModel{
+			CountryCode: c.countryCode,
+			Countries:   types.ListNull(GeoCountryModel{}.TFType()),
+			Cities:      types.ListNull(GeoCityModel{}.TFType()),
+		})
+		d.Read(context.Background(), req, resp)
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out GeoLocationsModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		var countries []GeoCountryModel
+		resp.Diagnostics.Append(out.Countries.ElementsAs(context.Background(), &countries, false)...)
+		var cities []GeoCityModel
+		if !out.Cities.IsNull() {
+			resp.Diagnostics.Append(out.Cities.ElementsAs(context.Background(), &cities, false)...)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		if len(countries) != len(c.expectedCountries) {
+			t.Fatalf("Expected countries %v, found %v", c.expectedCountries, countries)
+		}
+		for i, code := range c.expectedCountries {
+			if countries[i].CountryCode.ValueString() != code {
+				t.Fatalf("Expected countries %v, found %v", c.expectedCountries, countries)
+			}
+		}
+		if c.expectedCities == nil != out.Cities.IsNull() || len(cities) != len(c.expectedCities) {
+			t.Fatalf("Expected cities %v, found %v", c.expectedCities, out.Cities)
+		}
+		for i, name := range c.expectedCities {
+			if cities[i].CityName.ValueString() != name {
+				t.Fatalf("Expected cities %v, found %v", c.expectedCities, cities)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -26,6 +27,12 @@ type GroupDataSource struct {
 	client *netbird.Client
 }
 
+// GroupDataSourceModel describes the data source data model.
+type GroupDataSourceModel struct {
+	GroupModel
+	UseServerSearch types.Bool `tfsdk:"use_server_search"`
+}
+
 func (d *GroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_group"
 }
@@ -54,11 +61,27 @@ func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
-			"resources": schema.ListAttribute{
-				MarkdownDescription: "List of network resource ids",
-				ElementType:         types.StringType,
+			"resources": schema.SetNestedAttribute{
+				MarkdownDescription: "Set of network resources assigned to the group",
 				Computed:            true,
-			}},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Network resource ID",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Network resource type (host, subnet, domain)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"use_server_search": schema.BoolAttribute{
+				MarkdownDescription: "Look the group up by name on the Management API instead of listing all groups, reduces the response size on large accounts, the returned group is checked against `id` and `name` locally in case the server ignores the filter",
+				Optional:            true,
+			},
+		},
 	}
 }
 
@@ -68,22 +91,22 @@ func (d *GroupDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data GroupModel
+	var data GroupDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -97,10 +120,23 @@ func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	groups, err := d.client.Groups.List(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Error listing Groups", err.Error())
-		return
+	var groups []api.Group
+	if data.UseServerSearch.ValueBool() && !data.Name.IsNull() && !data.Name.IsUnknown() {
+		group, err := d.client.Groups.GetByName(ctx, data.Name.ValueString())
+		if err != nil && !errors.Is(err, netbird.ErrGroupNotFound) {
+			resp.Diagnostics.AddError("Error getting Group", formatAPIError(err))
+			return
+		}
+		if group != nil {
+			groups = []api.Group{*group}
+		}
+	} else {
+		var err error
+		groups, err = d.client.Groups.List(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing Groups", formatAPIError(err))
+			return
+		}
 	}
 
 	var group *api.Group
@@ -125,7 +161,7 @@ func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data)...)
+	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data.GroupModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupEnsure{}
+
+func NewGroupEnsure() resource.Resource {
+	return &GroupEnsure{}
+}
+
+// GroupEnsure defines the resource implementation.
+type GroupEnsure struct {
+	client *netbird.Client
+}
+
+// GroupEnsureModel describes the resource data model.
+type GroupEnsureModel struct {
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Created types.Bool   `tfsdk:"created"`
+}
+
+func (r *GroupEnsure) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_ensure"
+}
+
+func (r *GroupEnsure) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Ensure a Group exists by name",
+		MarkdownDescription: "Ensure a Group exists by name, an existing group with the same name is adopted instead of creating another one, see [NetBird Docs](https://docs.netbird.io/how-to/manage-network-access#groups) for more information. Adopted groups are left in place on destroy, only groups created by this resource are deleted.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Group ID",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Group name identifier",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"created": schema.BoolAttribute{
+				MarkdownDescription: "True if the group was created by this resource and is deleted on destroy, false if an existing group was adopted, including a group recreated outside Terraform under the same name",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r *GroupEnsure) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+// groupEnsureFind returns the group with the given name, nil if none exists.
+func groupEnsureFind(ctx context.Context, client *netbird.Client, name string) (*api.Group, error) {
+	groups, err := client.Groups.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *api.Group
+	for i, g := range groups {
+		if g.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("multiple groups are named %q (%s, %s)", name, found.Id, g.Id)
+		}
+		found = &groups[i]
+	}
+	return found, nil
+}
+
+func (r *GroupEnsure) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupEnsureModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := groupEnsureFind(ctx, r.client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Groups", formatAPIError(err))
+		return
+	}
+
+	data.Created = types.BoolValue(group == nil)
+	if group == nil {
+		group, err = r.client.Groups.Create(ctx, api.GroupRequest{Name: data.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating group", formatAPIError(err))
+			return
+		}
+	}
+	data.Id = types.StringValue(group.Id)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupEnsure) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupEnsureModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := groupEnsureFind(ctx, r.client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Groups", formatAPIError(err))
+		return
+	}
+
+	if group == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	// A different group now holds the name, it is adopted and must not be deleted on destroy
+	if group.Id != data.Id.ValueString() {
+		data.Created = types.BoolValue(false)
+	}
+	data.Id = types.StringValue(group.Id)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupEnsure) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupEnsureModel
+
+	// Read Terraform plan data into the model, name changes replace the resource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupEnsure) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupEnsureModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Adopted groups are owned elsewhere, only remove them from state
+	if !data.Created.ValueBool() {
+		return
+	}
+
+	err := r.client.Groups.Delete(ctx, data.Id.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Group", formatAPIError(err))
+	}
+}
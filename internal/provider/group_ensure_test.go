@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_GroupEnsure(t *testing.T) {
+	cases := []struct {
+		name            string
+		groups          string
+		expectedId      string
+		expectedCreated bool
+		expectedCalls   int
+	}{
+		{name: "create", groups: `[{"id":"g1","name":"Other"}]`, expectedId: "g2", expectedCreated: true, expectedCalls: 1},
+		{name: "adopt existing", groups: `[{"id":"g1","name":"Other"},{"id":"g3","name":"Ensured"}]`, expectedId: "g3", expectedCreated: false, expectedCalls: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			creates, deletes := 0, 0
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/groups":
+					_, _ = w.Write([]byte(c.groups))
+				case r.Method == http.MethodPost && r.URL.Path == "/api/groups":
+					creates++
+					_, _ = w.Write([]byte(`{"id":"g2","name":"Ensured","peers":[],"resources":[]}`))
+				case r.Method == http.MethodDelete:
+					deletes++
+					_, _ = w.Write([]byte(`{}`))
+				}
+			})
+
+			r := &GroupEnsure{client: client}
+			plan := testResourceState(t, r, &GroupEnsureModel{
+				Id:      types.StringNull(),
+				Name:    types.StringValue("Ensured"),
+				Created: types.BoolNull(),
+			})
+			resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+			r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+
+			var out GroupEnsureModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			if out.Id.ValueString() != c.expectedId || out.Created.ValueBool() != c.expectedCreated {
+				t.Fatalf("Expected group %s with created=%t, found %s with created=%t", c.expectedId, c.expectedCreated, out.Id.ValueString(), out.Created.ValueBool())
+			}
+			if creates != c.expectedCalls {
+				t.Fatalf("Expected %d create calls, found %d", c.expectedCalls, creates)
+			}
+
+			deleteResp := tfresource.DeleteResponse{}
+			r.Delete(context.Background(), tfresource.DeleteRequest{State: resp.State}, &deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", deleteResp.Diagnostics.Errors())
+			}
+			if deletes != c.expectedCalls {
+				t.Fatalf("Expected %d delete calls, found %d", c.expectedCalls, deletes)
+			}
+		})
+	}
+}
+
+func Test_GroupEnsure_Read(t *testing.T) {
+	cases := []struct {
+		groups          string
+		expectedId      string
+		expectedCreated bool
+		removed         bool
+		errors          int
+	}{
+		{groups: `[{"id":"g3","name":"Ensured"}]`, expectedId: "g3", expectedCreated: true},
+		{groups: `[{"id":"g4","name":"Ensured"}]`, expectedId: "g4", expectedCreated: false},
+		{groups: `[{"id":"g1","name":"Other"}]`, removed: true},
+		{groups: `[{"id":"g4","name":"Ensured"},{"id":"g5","name":"Ensured"}]`, errors: 1},
+	}
+
+	for _, c := range cases {
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(c.groups))
+		})
+
+		r := &GroupEnsure{client: client}
+		state := testResourceState(t, r, &GroupEnsureModel{
+			Id:      types.StringValue("g3"),
+			Name:    types.StringValue("Ensured"),
+			Created: types.BoolValue(true),
+		})
+		resp := tfresource.ReadResponse{State: state}
+		r.Read(context.Background(), tfresource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for %s, found %v", c.errors, c.groups, resp.Diagnostics.Errors())
+		}
+		if c.errors > 0 {
+			continue
+		}
+		if resp.State.Raw.IsNull() != c.removed {
+			t.Fatalf("Expected removed=%t for %s", c.removed, c.groups)
+		}
+		if c.removed {
+			continue
+		}
+
+		var out GroupEnsureModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		if out.Id.ValueString() != c.expectedId || out.Created.ValueBool() != c.expectedCreated {
+			t.Fatalf("Expected group %s with created=%t, found %s with created=%t", c.expectedId, c.expectedCreated, out.Id.ValueString(), out.Created.ValueBool())
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GroupMembershipsDataSource{}
+
+func NewGroupMembershipsDataSource() datasource.DataSource {
+	return &GroupMembershipsDataSource{}
+}
+
+// GroupMembershipsDataSource defines the data source implementation.
+type GroupMembershipsDataSource struct {
+	client *netbird.Client
+}
+
+// GroupMembershipsModel describes the data source data model.
+type GroupMembershipsModel struct {
+	Memberships types.List `tfsdk:"memberships"`
+}
+
+// GroupMembershipModel describes the peers of a single group.
+type GroupMembershipModel struct {
+	GroupId   types.String `tfsdk:"group_id"`
+	GroupName types.String `tfsdk:"group_name"`
+	PeerIds   types.List   `tfsdk:"peer_ids"`
+}
+
+// TFType returns the Terraform object type for group memberships.
+func (m GroupMembershipModel) TFType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"group_id":   types.StringType,
+			"group_name": types.StringType,
+			"peer_ids":   types.ListType{ElemType: types.StringType},
+		},
+	}
+}
+
+func (d *GroupMembershipsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_memberships"
+}
+
+func (d *GroupMembershipsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Read the Peers of every Group",
+		MarkdownDescription: "Read the Peers of every Group, for reporting which groups each peer belongs to, see [NetBird Docs](https://docs.netbird.io/how-to/manage-network-access#groups) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"memberships": schema.ListNestedAttribute{
+				MarkdownDescription: "Groups with their peers, ordered by group name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group_id": schema.StringAttribute{
+							MarkdownDescription: "Group ID",
+							Computed:            true,
+						},
+						"group_name": schema.StringAttribute{
+							MarkdownDescription: "Group Name",
+							Computed:            true,
+						},
+						"peer_ids": schema.ListAttribute{
+							MarkdownDescription: "IDs of the peers in the group, ordered by ID",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *GroupMembershipsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// groupMemberships returns the peer IDs of each group, ordered by group name and then group ID.
+func groupMemberships(ctx context.Context, groups []api.Group) (types.List, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	groups = slices.Clone(groups)
+	slices.SortFunc(groups, func(a, b api.Group) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Id, b.Id))
+	})
+
+	memberships := make([]GroupMembershipModel, len(groups))
+	for i, g := range groups {
+		peerIds := make([]string, len(g.Peers))
+		for j, p := range g.Peers {
+			peerIds[j] = p.Id
+		}
+		slices.Sort(peerIds)
+		ids, d := types.ListValueFrom(ctx, types.StringType, peerIds)
+		ret.Append(d...)
+		memberships[i] = GroupMembershipModel{
+			GroupId:   types.StringValue(g.Id),
+			GroupName: types.StringValue(g.Name),
+			PeerIds:   ids,
+		}
+	}
+	if ret.HasError() {
+		return types.ListNull(GroupMembershipModel{}.TFType()), ret
+	}
+
+	l, d := types.ListValueFrom(ctx, GroupMembershipModel{}.TFType(), memberships)
+	ret.Append(d...)
+	return l, ret
+}
+
+func (d *GroupMembershipsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GroupMembershipsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.Groups.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Groups", formatAPIError(err))
+		return
+	}
+
+	memberships, di := groupMemberships(ctx, groups)
+	resp.Diagnostics.Append(di...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Memberships = memberships
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
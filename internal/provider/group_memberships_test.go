@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+func Test_GroupMembershipsDataSource_Read(t *testing.T) {
+	groups := []api.Group{
+		{Id: "g2", Name: "ops", Peers: []api.PeerMinimum{{Id: "p3", Name: "db"}, {Id: "p1", Name: "web"}}},
+		{Id: "g1", Name: "devs", Peers: []api.PeerMinimum{{Id: "p2", Name: "laptop"}, {Id: "p1", Name: "web"}}},
+		{Id: "g3", Name: "empty", Peers: []api.PeerMinimum{}},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(groups)
+	})
+
+	d := &GroupMembershipsDataSource{client: client}
+	req, resp := testDataSourceRead(t, d, &GroupMembershipsModel{
+		Memberships: types.ListNull(GroupMembershipModel{}.TFType()),
+	})
+	d.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var out GroupMembershipsModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	var memberships []GroupMembershipModel
+	resp.Diagnostics.Append(out.Memberships.ElementsAs(context.Background(), &memberships, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	found := map[string][]string{}
+	names := []string{}
+	for _, m := range memberships {
+		var ids []string
+		resp.Diagnostics.Append(m.PeerIds.ElementsAs(context.Background(), &ids, false)...)
+		found[m.GroupId.ValueString()] = ids
+		names = append(names, m.GroupName.ValueString())
+	}
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	if !reflect.DeepEqual(names, []string{"devs", "empty", "ops"}) {
+		t.Fatalf("Expected groups ordered by name [devs empty ops], found %v", names)
+	}
+	expected := map[string][]string{
+		"g1": {"p1", "p2"},
+		"g2": {"p1", "p3"},
+		"g3": {},
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Fatalf("Expected memberships %v, found %v", expected, found)
+	}
+}
@@ -6,16 +6,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -27,6 +32,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Group{}
 var _ resource.ResourceWithImportState = &Group{}
+var _ resource.ResourceWithUpgradeState = &Group{}
 
 func NewGroup() resource.Resource {
 	return &Group{}
@@ -37,21 +43,44 @@ type Group struct {
 	client *netbird.Client
 }
 
-// GroupModel describes the resource data model.
+// GroupModel describes the group data model shared by the resource and data source.
 type GroupModel struct {
 	Id        types.String `tfsdk:"id"`
 	Name      types.String `tfsdk:"name"`
 	Peers     types.List   `tfsdk:"peers"`
-	Resources types.List   `tfsdk:"resources"`
+	Resources types.Set    `tfsdk:"resources"`
 	Issued    types.String `tfsdk:"issued"`
 }
 
+// GroupResourceModel describes the resource data model.
+type GroupResourceModel struct {
+	GroupModel
+	AllowDuplicateName types.Bool `tfsdk:"allow_duplicate_name"`
+}
+
+// GroupNetworkResourceModel describes a network resource assigned to a group.
+type GroupNetworkResourceModel struct {
+	Id   types.String `tfsdk:"id"`
+	Type types.String `tfsdk:"type"`
+}
+
+// TFType returns the Terraform object type for group resources.
+func (m GroupNetworkResourceModel) TFType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":   types.StringType,
+			"type": types.StringType,
+		},
+	}
+}
+
 func (r *Group) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_group"
 }
 
 func (r *Group) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		Description:         "Create and assign Groups",
 		MarkdownDescription: "Create and assign Groups, see [NetBird Docs](https://docs.netbird.io/how-to/manage-network-access#groups) for more information.",
 
@@ -79,16 +108,82 @@ func (r *Group) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()},
 				Validators:          []validator.List{listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
 			},
-			"resources": schema.ListAttribute{
-				MarkdownDescription: "List of network resource ids",
-				ElementType:         types.StringType,
+			"resources": schema.SetNestedAttribute{
+				MarkdownDescription: "Set of network resources assigned to the group",
 				Computed:            true,
 				Optional:            true,
-				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()},
-				Validators:          []validator.List{listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
+				PlanModifiers:       []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Network resource ID",
+							Required:            true,
+							Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Network resource type (host, subnet, domain)",
+							Required:            true,
+							Validators:          []validator.String{stringvalidator.OneOf("host", "subnet", "domain")},
+						},
+					},
+				},
+			},
+			"allow_duplicate_name": schema.BoolAttribute{
+				MarkdownDescription: "Create the group even if a group with the same name already exists, by default creation fails to keep name-based lookups unambiguous",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// groupResourceModelV0 describes the resource data model before resources held their type.
+type groupResourceModelV0 struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Peers     types.List   `tfsdk:"peers"`
+	Resources types.List   `tfsdk:"resources"`
+	Issued    types.String `tfsdk:"issued"`
+}
+
+func (r *Group) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":        schema.StringAttribute{Computed: true},
+					"name":      schema.StringAttribute{Required: true},
+					"issued":    schema.StringAttribute{Computed: true},
+					"peers":     schema.ListAttribute{ElementType: types.StringType, Computed: true, Optional: true},
+					"resources": schema.ListAttribute{ElementType: types.StringType, Computed: true, Optional: true},
+				},
 			},
+			StateUpgrader: groupUpgradeStateV0,
+		},
+	}
+}
+
+// groupUpgradeStateV0 upgrades resources from a list of IDs to a set of id and type objects,
+// the prior state has no resource types so resources are left null for the following read to fill in.
+func groupUpgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior groupResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := GroupResourceModel{
+		GroupModel: GroupModel{
+			Id:        prior.Id,
+			Name:      prior.Name,
+			Peers:     prior.Peers,
+			Resources: types.SetNull(GroupNetworkResourceModel{}.TFType()),
+			Issued:    prior.Issued,
 		},
+		AllowDuplicateName: types.BoolValue(false),
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Group) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -97,18 +192,18 @@ func (r *Group) Configure(ctx context.Context, req resource.ConfigureRequest, re
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func groupAPIToTerraform(ctx context.Context, group *api.Group, data *GroupModel) diag.Diagnostics {
@@ -123,18 +218,93 @@ func groupAPIToTerraform(ctx context.Context, group *api.Group, data *GroupModel
 	l, diag := types.ListValueFrom(ctx, types.StringType, peers)
 	ret.Append(diag...)
 	data.Peers = l
-	resources := make([]string, len(group.Resources))
+	resources := make([]GroupNetworkResourceModel, len(group.Resources))
 	for i, j := range group.Resources {
-		resources[i] = j.Id
+		resources[i] = GroupNetworkResourceModel{
+			Id:   types.StringValue(j.Id),
+			Type: types.StringValue(string(j.Type)),
+		}
 	}
-	l, diag = types.ListValueFrom(ctx, types.StringType, resources)
+	s, diag := types.SetValueFrom(ctx, GroupNetworkResourceModel{}.TFType(), resources)
 	ret.Append(diag...)
-	data.Resources = l
+	data.Resources = s
+	return ret
+}
+
+func groupResourcesTerraformToAPI(ctx context.Context, data GroupModel) (*[]api.Resource, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	if data.Resources.IsNull() || data.Resources.IsUnknown() {
+		return nil, ret
+	}
+
+	var tfVal []GroupNetworkResourceModel
+	ret.Append(data.Resources.ElementsAs(ctx, &tfVal, false)...)
+	if ret.HasError() {
+		return nil, ret
+	}
+
+	resources := make([]api.Resource, len(tfVal))
+	for i, v := range tfVal {
+		resources[i] = api.Resource{
+			Id:   v.Id.ValueString(),
+			Type: api.ResourceType(v.Type.ValueString()),
+		}
+	}
+	return &resources, ret
+}
+
+// groupCheckDuplicateName checks that no group with the configured name exists unless allow_duplicate_name is enabled.
+func groupCheckDuplicateName(ctx context.Context, client *netbird.Client, data GroupResourceModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	if data.AllowDuplicateName.ValueBool() {
+		return ret
+	}
+
+	groups, err := client.Groups.List(ctx)
+	if err != nil {
+		ret.AddError("Error listing Groups", formatAPIError(err))
+		return ret
+	}
+	for _, g := range groups {
+		if g.Name == data.Name.ValueString() {
+			ret.AddAttributeError(path.Root("name"), "Duplicate Group Name", fmt.Sprintf("Group %q already exists with ID %s, import it or set allow_duplicate_name to create another group with the same name", g.Name, g.Id))
+			return ret
+		}
+	}
+	return ret
+}
+
+// validateGroupIDs checks that all group IDs in ids exist, reporting every missing group in a single error.
+func validateGroupIDs(ctx context.Context, client *netbird.Client, ids []string) diag.Diagnostics {
+	var ret diag.Diagnostics
+	if len(ids) == 0 {
+		return ret
+	}
+
+	groups, err := client.Groups.List(ctx)
+	if err != nil {
+		ret.AddError("Error listing Groups", formatAPIError(err))
+		return ret
+	}
+	existing := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		existing[g.Id] = struct{}{}
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if _, ok := existing[id]; !ok && !slices.Contains(missing, strconv.Quote(id)) {
+			missing = append(missing, strconv.Quote(id))
+		}
+	}
+	if len(missing) > 0 {
+		ret.AddError("Group Not Found", fmt.Sprintf("Groups %s do not exist, groups must be referenced by the ID of an existing group", strings.Join(missing, ", ")))
+	}
 	return ret
 }
 
 func (r *Group) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data GroupModel
+	var data GroupResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -143,21 +313,15 @@ func (r *Group) Create(ctx context.Context, req resource.CreateRequest, resp *re
 		return
 	}
 
-	var resources *[]api.Resource
-	if len(data.Resources.Elements()) > 0 {
-		var tfVal []map[string]string
-		var resourcesVal []api.Resource
-		resp.Diagnostics.Append(data.Resources.ElementsAs(ctx, &tfVal, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		for _, v := range tfVal {
-			resourcesVal = append(resourcesVal, api.Resource{
-				Id:   v["id"],
-				Type: api.ResourceType(v["type"]),
-			})
-		}
-		resources = &resourcesVal
+	resp.Diagnostics.Append(groupCheckDuplicateName(ctx, r.client, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resources, d := groupResourcesTerraformToAPI(ctx, data.GroupModel)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	groupReq := api.GroupRequest{
@@ -168,11 +332,11 @@ func (r *Group) Create(ctx context.Context, req resource.CreateRequest, resp *re
 
 	group, err := r.client.Groups.Create(ctx, groupReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating group", err.Error())
+		resp.Diagnostics.AddError("Error creating group", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data)...)
+	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data.GroupModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -183,7 +347,7 @@ func (r *Group) Create(ctx context.Context, req resource.CreateRequest, resp *re
 }
 
 func (r *Group) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data GroupModel
+	var data GroupResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -198,23 +362,30 @@ func (r *Group) Read(ctx context.Context, req resource.ReadRequest, resp *resour
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting Group", err.Error())
+			resp.Diagnostics.AddError("Error getting Group", formatAPIError(err))
 		}
 		return
 	}
 
-	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data)...)
+	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data.GroupModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if group.Issued != nil && *group.Issued == api.GroupIssuedJwt {
+		resp.Diagnostics.AddWarning(
+			"JWT Issued Group",
+			fmt.Sprintf("Group %s (%s) was created from JWT group claims, with JWT group sync enabled in the account settings NetBird may change its peers on user logins, conflicting with the peers managed by Terraform.", group.Name, group.Id),
+		)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Group) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data GroupModel
+	var data GroupResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -228,21 +399,10 @@ func (r *Group) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 		return
 	}
 
-	var resources *[]api.Resource
-	if len(data.Resources.Elements()) > 0 {
-		var tfVal []map[string]string
-		var resourcesVal []api.Resource
-		resp.Diagnostics.Append(data.Resources.ElementsAs(ctx, &tfVal, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		for _, v := range tfVal {
-			resourcesVal = append(resourcesVal, api.Resource{
-				Id:   v["id"],
-				Type: api.ResourceType(v["type"]),
-			})
-		}
-		resources = &resourcesVal
+	resources, d := groupResourcesTerraformToAPI(ctx, data.GroupModel)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	groupReq := api.GroupRequest{
@@ -253,11 +413,11 @@ func (r *Group) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 
 	group, err := r.client.Groups.Update(ctx, data.Id.ValueString(), groupReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Group", err.Error())
+		resp.Diagnostics.AddError("Error updating Group", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data)...)
+	resp.Diagnostics.Append(groupAPIToTerraform(ctx, group, &data.GroupModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -267,7 +427,7 @@ func (r *Group) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 }
 
 func (r *Group) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data GroupModel
+	var data GroupResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -277,11 +437,12 @@ func (r *Group) Delete(ctx context.Context, req resource.DeleteRequest, resp *re
 	}
 
 	err := r.client.Groups.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting Group", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Group", formatAPIError(err))
 	}
 }
 
 func (r *Group) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_duplicate_name"), false)...)
 }
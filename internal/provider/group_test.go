@@ -3,11 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -34,7 +39,7 @@ func Test_groupAPIToTerraform(t *testing.T) {
 				Issued:    types.StringValue("api"),
 				Name:      types.StringValue("Test"),
 				Peers:     types.ListValueMust(types.StringType, []attr.Value{}),
-				Resources: types.ListValueMust(types.StringType, []attr.Value{}),
+				Resources: types.SetValueMust(GroupNetworkResourceModel{}.TFType(), []attr.Value{}),
 			},
 		},
 		{
@@ -65,11 +70,20 @@ func Test_groupAPIToTerraform(t *testing.T) {
 				},
 			},
 			expected: GroupModel{
-				Id:        types.StringValue("def"),
-				Issued:    types.StringNull(),
-				Name:      types.StringValue("Meow"),
-				Peers:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("c1"), types.StringValue("c2")}),
-				Resources: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("r1"), types.StringValue("r2")}),
+				Id:     types.StringValue("def"),
+				Issued: types.StringNull(),
+				Name:   types.StringValue("Meow"),
+				Peers:  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("c1"), types.StringValue("c2")}),
+				Resources: types.SetValueMust(GroupNetworkResourceModel{}.TFType(), []attr.Value{
+					types.ObjectValueMust(GroupNetworkResourceModel{}.TFType().AttrTypes, map[string]attr.Value{
+						"id":   types.StringValue("r1"),
+						"type": types.StringValue("domain"),
+					}),
+					types.ObjectValueMust(GroupNetworkResourceModel{}.TFType().AttrTypes, map[string]attr.Value{
+						"id":   types.StringValue("r2"),
+						"type": types.StringValue("subnet"),
+					}),
+				}),
 			},
 		},
 	}
@@ -87,6 +101,246 @@ func Test_groupAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_Group_Create_duplicateName(t *testing.T) {
+	cases := []struct {
+		name               string
+		allowDuplicateName bool
+		expectedCreates    int
+		expectedError      string
+	}{
+		{name: "rejected", allowDuplicateName: false, expectedCreates: 0, expectedError: "Duplicate Group Name"},
+		{name: "allowed", allowDuplicateName: true, expectedCreates: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			creates := 0
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					creates++
+					_, _ = w.Write([]byte(`{"id":"g2","name":"Existing","peers":[],"resources":[]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`[{"id":"g1","name":"Existing","peers":[],"resources":[]}]`))
+			})
+
+			r := &Group{client: client}
+			plan := testResourceState(t, r, &GroupResourceModel{
+				GroupModel: GroupModel{
+					Name:      types.StringValue("Existing"),
+					Peers:     types.ListNull(types.StringType),
+					Resources: types.SetNull(GroupNetworkResourceModel{}.TFType()),
+				},
+				AllowDuplicateName: types.BoolValue(c.allowDuplicateName),
+			})
+			resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+			r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+			if c.expectedError == "" && resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			if c.expectedError != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+					t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+				}
+				if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "g1") {
+					t.Fatalf("Expected error to name the existing group, found %s", resp.Diagnostics.Errors()[0].Detail())
+				}
+			}
+			if creates != c.expectedCreates {
+				t.Fatalf("Expected %d create requests, found %d", c.expectedCreates, creates)
+			}
+		})
+	}
+}
+
+func Test_Group_Read_jwtIssued(t *testing.T) {
+	cases := []struct {
+		issued   string
+		warnings int
+	}{
+		{issued: `"api"`},
+		{issued: `null`},
+		{issued: `"jwt"`, warnings: 1},
+	}
+
+	for _, c := range cases {
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"g1","name":"developers","issued":` + c.issued + `,"peers":[],"resources":[]}`))
+		})
+
+		r := &Group{client: client}
+		state := testResourceState(t, r, &GroupResourceModel{
+			GroupModel: GroupModel{
+				Id:        types.StringValue("g1"),
+				Name:      types.StringValue("developers"),
+				Peers:     types.ListNull(types.StringType),
+				Resources: types.SetNull(GroupNetworkResourceModel{}.TFType()),
+			},
+			AllowDuplicateName: types.BoolValue(false),
+		})
+		resp := tfresource.ReadResponse{State: state}
+		r.Read(context.Background(), tfresource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if resp.Diagnostics.WarningsCount() != c.warnings {
+			t.Fatalf("Expected %d warnings for issued %s, found %v", c.warnings, c.issued, resp.Diagnostics.Warnings())
+		}
+	}
+}
+
+func Test_Group_UpgradeState_v0(t *testing.T) {
+	r := &Group{}
+	upgrader := r.UpgradeState(context.Background())[0]
+	prior := tfsdk.State{
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(context.Background()), nil),
+		Schema: *upgrader.PriorSchema,
+	}
+	diags := prior.Set(context.Background(), &groupResourceModelV0{
+		Id:        types.StringValue("g1"),
+		Name:      types.StringValue("developers"),
+		Issued:    types.StringValue("api"),
+		Peers:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("peer1")}),
+		Resources: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("resource1")}),
+	})
+	if diags.HasError() {
+		t.Fatalf("Failed to build prior state: %v", diags.Errors())
+	}
+
+	current := testResourceState(t, r, &GroupResourceModel{
+		GroupModel: GroupModel{
+			Peers:     types.ListNull(types.StringType),
+			Resources: types.SetNull(GroupNetworkResourceModel{}.TFType()),
+		},
+	})
+	resp := tfresource.UpgradeStateResponse{State: tfsdk.State{Schema: current.Schema}}
+	upgrader.StateUpgrader(context.Background(), tfresource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var data GroupResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	if data.Id.ValueString() != "g1" || data.Name.ValueString() != "developers" || len(data.Peers.Elements()) != 1 {
+		t.Fatalf("Expected group g1 developers with 1 peer, found %s %s %s", data.Id, data.Name, data.Peers)
+	}
+	if !data.Resources.IsNull() {
+		t.Fatalf("Expected null resources, found %s", data.Resources)
+	}
+	if data.AllowDuplicateName.ValueBool() {
+		t.Fatalf("Expected allow_duplicate_name false, found %s", data.AllowDuplicateName)
+	}
+}
+
+func Test_validateGroupIDs(t *testing.T) {
+	cases := []struct {
+		name             string
+		ids              []string
+		status           int
+		expectedRequests int
+		expectedError    string
+		expectedDetail   string
+	}{
+		{name: "empty", ids: nil, expectedRequests: 0},
+		{name: "existing", ids: []string{"g1", "g2"}, status: http.StatusOK, expectedRequests: 1},
+		{name: "missing", ids: []string{"g1", "g3", "g4", "g3"}, status: http.StatusOK, expectedRequests: 1, expectedError: "Group Not Found", expectedDetail: `Groups "g3", "g4" do not exist`},
+		{name: "list error", ids: []string{"g1"}, status: http.StatusInternalServerError, expectedRequests: 1, expectedError: "Error listing Groups"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			requests := 0
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(c.status)
+				if c.status != http.StatusOK {
+					_, _ = w.Write([]byte(`{"message":"internal error","code":500}`))
+					return
+				}
+				_, _ = w.Write([]byte(`[{"id":"g1","name":"G1","peers":[],"resources":[]},{"id":"g2","name":"G2","peers":[],"resources":[]}]`))
+			})
+
+			diags := validateGroupIDs(context.Background(), client, c.ids)
+			if c.expectedError == "" && diags.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+			}
+			if c.expectedError != "" {
+				if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != c.expectedError {
+					t.Fatalf("Expected %s error, found %v", c.expectedError, diags.Errors())
+				}
+				if !strings.Contains(diags.Errors()[0].Detail(), c.expectedDetail) {
+					t.Fatalf("Expected error detail to contain %s, found %s", c.expectedDetail, diags.Errors()[0].Detail())
+				}
+			}
+			if requests != c.expectedRequests {
+				t.Fatalf("Expected %d requests, found %d", c.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func Test_GroupDataSource_Read_serverSearch(t *testing.T) {
+	cases := []struct {
+		useServerSearch types.Bool
+		ignoreFilter    bool
+		expectedQuery   string
+		expectedError   string
+	}{
+		{useServerSearch: types.BoolValue(true), expectedQuery: "name=Devs"},
+		{useServerSearch: types.BoolValue(true), ignoreFilter: true, expectedQuery: "name=Devs", expectedError: "No match"},
+		{useServerSearch: types.BoolValue(false), expectedQuery: ""},
+		{useServerSearch: types.BoolNull(), expectedQuery: ""},
+	}
+
+	for _, c := range cases {
+		var query string
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("name") == "Devs" && !c.ignoreFilter {
+				_, _ = w.Write([]byte(`[{"id":"g2","name":"Devs","peers":[],"resources":[]}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id":"g1","name":"Admins","peers":[],"resources":[]},{"id":"g2","name":"Devs","peers":[],"resources":[]}]`))
+		})
+
+		d := &GroupDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &GroupDataSourceModel{
+			GroupModel: GroupModel{
+				Id:        types.StringNull(),
+				Name:      types.StringValue("Devs"),
+				Peers:     types.ListNull(types.StringType),
+				Resources: types.SetNull(GroupNetworkResourceModel{}.TFType()),
+			},
+			UseServerSearch: c.useServerSearch,
+		})
+		d.Read(context.Background(), req, resp)
+		if query != c.expectedQuery {
+			t.Fatalf("Expected query %q with use_server_search=%s, found %q", c.expectedQuery, c.useServerSearch, query)
+		}
+		// A server ignoring the filter returns another group first, which does not match locally
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out GroupDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		if out.Id.ValueString() != "g2" {
+			t.Fatalf("Expected group g2, found %s", out.Id.ValueString())
+		}
+	}
+}
+
 func Test_Group_Create(t *testing.T) {
 	rName := "g" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_group." + rName
@@ -172,6 +426,68 @@ func Test_Group_Update(t *testing.T) {
 	})
 }
 
+func Test_Group_Resources(t *testing.T) {
+	rName := "g" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_group." + rName
+	rNetworkResourceFull := "netbird_network_resource." + rName
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: rName,
+				Config: fmt.Sprintf(`resource "netbird_network" "%[1]s" {
+	name = "%[1]s"
+}
+
+resource "netbird_group" "%[1]s_holder" {
+	name = "%[1]s-holder"
+}
+
+resource "netbird_network_resource" "%[1]s" {
+	network_id = netbird_network.%[1]s.id
+	address = "%[1]s.example.com"
+	name = "%[1]s"
+	groups = [netbird_group.%[1]s_holder.id]
+
+	lifecycle {
+		ignore_changes = [groups]
+	}
+}
+
+resource "netbird_group" "%[1]s" {
+	name = "%[1]s"
+	resources = [{
+		id   = netbird_network_resource.%[1]s.id
+		type = "domain"
+	}]
+}`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(rNameFull, "id"),
+					resource.TestCheckResourceAttr(rNameFull, "resources.#", "1"),
+					resource.TestCheckResourceAttrPair(rNameFull, "resources.0.id", rNetworkResourceFull, "id"),
+					resource.TestCheckResourceAttr(rNameFull, "resources.0.type", "domain"),
+					func(s *terraform.State) error {
+						gID := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+						rID := s.RootModule().Resources[rNetworkResourceFull].Primary.Attributes["id"]
+						group, err := testClient().Groups.Get(context.Background(), gID)
+						if err != nil {
+							return err
+						}
+						if len(group.Resources) != 1 {
+							return fmt.Errorf("Group Resources mismatch, expected 1 resource, found %d on management server", len(group.Resources))
+						}
+						if group.Resources[0].Id != rID || group.Resources[0].Type != api.ResourceTypeDomain {
+							return fmt.Errorf("Group Resources mismatch, expected %s (domain), found %s (%s) on management server", rID, group.Resources[0].Id, group.Resources[0].Type)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testGroupResource(rName, peers string) string {
 	return fmt.Sprintf(`resource "netbird_group" "%s" {
 	name = "%s"
@@ -76,16 +76,16 @@ func (d *IdentityProviderDataSource) Configure(ctx context.Context, req datasour
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *IdentityProviderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -103,7 +103,7 @@ func (d *IdentityProviderDataSource) Read(ctx context.Context, req datasource.Re
 
 	idps, err := d.client.IdentityProviders.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Identity Providers", err.Error())
+		resp.Diagnostics.AddError("Error listing Identity Providers", formatAPIError(err))
 		return
 	}
 
@@ -90,16 +90,16 @@ func (r *IdentityProvider) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func identityProviderAPIToTerraform(idp *api.IdentityProvider, data *IdentityProviderModel) {
@@ -132,7 +132,7 @@ func (r *IdentityProvider) Create(ctx context.Context, req resource.CreateReques
 
 	idp, err := r.client.IdentityProviders.Create(ctx, idpReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Identity Provider", err.Error())
+		resp.Diagnostics.AddError("Error creating Identity Provider", formatAPIError(err))
 		return
 	}
 
@@ -155,7 +155,7 @@ func (r *IdentityProvider) Read(ctx context.Context, req resource.ReadRequest, r
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error getting Identity Provider", err.Error())
+		resp.Diagnostics.AddError("Error getting Identity Provider", formatAPIError(err))
 		return
 	}
 
@@ -181,7 +181,7 @@ func (r *IdentityProvider) Update(ctx context.Context, req resource.UpdateReques
 
 	idp, err := r.client.IdentityProviders.Update(ctx, data.Id.ValueString(), idpReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Identity Provider", err.Error())
+		resp.Diagnostics.AddError("Error updating Identity Provider", formatAPIError(err))
 		return
 	}
 
@@ -199,8 +199,8 @@ func (r *IdentityProvider) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	err := r.client.IdentityProviders.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting Identity Provider", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Identity Provider", formatAPIError(err))
 	}
 }
 
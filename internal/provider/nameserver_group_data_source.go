@@ -100,18 +100,18 @@ func (d *NameserverGroupDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *NameserverGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -131,7 +131,7 @@ func (d *NameserverGroupDataSource) Read(ctx context.Context, req datasource.Rea
 
 	nsGroups, err := d.client.DNS.ListNameserverGroups(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing NameserverGroups", err.Error())
+		resp.Diagnostics.AddError("Error listing NameserverGroups", formatAPIError(err))
 		return
 	}
 
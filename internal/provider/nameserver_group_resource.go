@@ -36,6 +36,7 @@ const (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NameserverGroup{}
 var _ resource.ResourceWithImportState = &NameserverGroup{}
+var _ resource.ResourceWithConfigValidators = &NameserverGroup{}
 
 func NewNameserverGroup() resource.Resource {
 	return &NameserverGroup{}
@@ -80,7 +81,7 @@ func (r *NameserverGroup) Schema(ctx context.Context, req resource.SchemaRequest
 				Validators:          []validator.String{stringvalidator.LengthBetween(1, 40)},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Description of the nameserver group",
+				MarkdownDescription: "Description of the nameserver group, an empty string when not set",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
@@ -104,14 +105,16 @@ func (r *NameserverGroup) Schema(ctx context.Context, req resource.SchemaRequest
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"ip": schema.StringAttribute{
-							MarkdownDescription: "Nameserver IP",
+							MarkdownDescription: "Nameserver IP, the Management API does not support nameservers referenced by hostname",
 							Required:            true,
+							Validators:          []validator.String{ipAddressValidator{}},
 						},
 						"ns_type": schema.StringAttribute{
-							MarkdownDescription: "Nameserver Type",
+							MarkdownDescription: "Nameserver Type, the Management API only supports `udp`",
 							Optional:            true,
 							Computed:            true,
-							Default:             stringdefault.StaticString("udp"),
+							Default:             stringdefault.StaticString(string(api.NameserverNsTypeUdp)),
+							Validators:          []validator.String{stringvalidator.OneOf(string(api.NameserverNsTypeUdp))},
 						},
 						"port": schema.Int32Attribute{
 							MarkdownDescription: "Nameserver Port",
@@ -151,18 +154,18 @@ func (r *NameserverGroup) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func nameserverGroupAPIToTerraform(ctx context.Context, nameserverGroup *api.NameserverGroup, data *NameserverGroupModel) diag.Diagnostics {
@@ -170,7 +173,7 @@ func nameserverGroupAPIToTerraform(ctx context.Context, nameserverGroup *api.Nam
 	var d diag.Diagnostics
 	data.Id = types.StringValue(nameserverGroup.Id)
 	data.Name = types.StringValue(nameserverGroup.Name)
-	data.Description = types.StringValue(nameserverGroup.Description)
+	data.Description = normalizeDescription(&nameserverGroup.Description)
 	data.Enabled = types.BoolValue(nameserverGroup.Enabled)
 	data.SearchDomainsEnabled = types.BoolValue(nameserverGroup.SearchDomainsEnabled)
 	data.Primary = types.BoolValue(nameserverGroup.Primary)
@@ -201,6 +204,56 @@ func nameserverGroupAPIToTerraform(ctx context.Context, nameserverGroup *api.Nam
 	return ret
 }
 
+// nameserverGroupValidate checks that primary, domains and search_domains_enabled are consistent.
+func nameserverGroupValidate(domainCount int, primary, searchDomainsEnabled bool) diag.Diagnostics {
+	var ret diag.Diagnostics
+	if searchDomainsEnabled && primary {
+		ret.AddError("Invalid Value", "search_domains_enabled and primary cannot be both true")
+		return ret
+	}
+
+	if domainCount != 0 && primary {
+		ret.AddError("Invalid Value", "nameserver group primary status is true and domains are not empty, you should set either primary or domain")
+		return ret
+	}
+
+	if domainCount == 0 && !primary {
+		ret.AddError("Invalid Value", "nameserver group primary status is false and domains are empty, it should be primary or have at least one domain")
+	}
+	return ret
+}
+
+// nameserverGroupConfigValidator runs the nameserverGroupValidate checks on configuration, so they fail at plan time.
+type nameserverGroupConfigValidator struct{}
+
+func (v nameserverGroupConfigValidator) Description(ctx context.Context) string {
+	return "primary must be true only if domains is empty, and search_domains_enabled must be true only if primary is false"
+}
+
+func (v nameserverGroupConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v nameserverGroupConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NameserverGroupModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values are only known once all references are resolved, checks are repeated at apply time
+	if data.Domains.IsUnknown() || data.Primary.IsUnknown() || data.SearchDomainsEnabled.IsUnknown() {
+		return
+	}
+
+	domainCount := len(data.Domains.Elements())
+	resp.Diagnostics.Append(nameserverGroupValidate(domainCount, boolDefault(data.Primary, domainCount == 0), boolDefault(data.SearchDomainsEnabled, false))...)
+}
+
+func (r *NameserverGroup) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{nameserverGroupConfigValidator{}}
+}
+
 func nameserverGroupTerraformToAPI(ctx context.Context, data *NameserverGroupModel) (api.NameserverGroupRequest, diag.Diagnostics) {
 	var ret diag.Diagnostics
 	nameserverGroupReq := api.NameserverGroupRequest{
@@ -214,18 +267,8 @@ func nameserverGroupTerraformToAPI(ctx context.Context, data *NameserverGroupMod
 		Nameservers:          make([]api.Nameserver, len(data.Nameservers.Elements())),
 	}
 
-	if nameserverGroupReq.SearchDomainsEnabled && nameserverGroupReq.Primary {
-		ret.AddError("Invalid Value", "search_domains_enabled and primary cannot be both true")
-		return nameserverGroupReq, ret
-	}
-
-	if len(nameserverGroupReq.Domains) != 0 && nameserverGroupReq.Primary {
-		ret.AddError("Invalid Value", "nameserver group primary status is true and domains are not empty, you should set either primary or domain")
-		return nameserverGroupReq, ret
-	}
-
-	if len(nameserverGroupReq.Domains) == 0 && !nameserverGroupReq.Primary {
-		ret.AddError("Invalid Value", "nameserver group primary status is false and domains are empty, it should be primary or have at least one domain")
+	ret.Append(nameserverGroupValidate(len(nameserverGroupReq.Domains), nameserverGroupReq.Primary, nameserverGroupReq.SearchDomainsEnabled)...)
+	if ret.HasError() {
 		return nameserverGroupReq, ret
 	}
 
@@ -278,7 +321,7 @@ func (r *NameserverGroup) Create(ctx context.Context, req resource.CreateRequest
 
 	nameserverGroup, err := r.client.DNS.CreateNameserverGroup(ctx, nameserverGroupReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating nameserverGroup", err.Error())
+		resp.Diagnostics.AddError("Error creating nameserverGroup", formatAPIError(err))
 		return
 	}
 
@@ -308,7 +351,7 @@ func (r *NameserverGroup) Read(ctx context.Context, req resource.ReadRequest, re
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting NameserverGroup", err.Error())
+			resp.Diagnostics.AddError("Error getting NameserverGroup", formatAPIError(err))
 		}
 		return
 	}
@@ -346,7 +389,7 @@ func (r *NameserverGroup) Update(ctx context.Context, req resource.UpdateRequest
 
 	nameserverGroup, err := r.client.DNS.UpdateNameserverGroup(ctx, data.Id.ValueString(), nameserverGroupReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating NameserverGroup", err.Error())
+		resp.Diagnostics.AddError("Error updating NameserverGroup", formatAPIError(err))
 		return
 	}
 
@@ -370,8 +413,8 @@ func (r *NameserverGroup) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	err := r.client.DNS.DeleteNameserverGroup(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting NameserverGroup", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting NameserverGroup", formatAPIError(err))
 	}
 }
 
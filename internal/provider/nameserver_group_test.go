@@ -8,6 +8,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -177,6 +181,117 @@ func Test_nameserverGroupTerraformToAPI(t *testing.T) {
 	}
 }
 
+func Test_nameserverGroupConfigValidator(t *testing.T) {
+	noDomains := types.ListValueMust(types.StringType, []attr.Value{})
+	domains := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("example.com")})
+	cases := []struct {
+		name                 string
+		domains              types.List
+		primary              types.Bool
+		searchDomainsEnabled types.Bool
+		errors               int
+	}{
+		{
+			name:                 "primary with search domains",
+			domains:              noDomains,
+			primary:              types.BoolValue(true),
+			searchDomainsEnabled: types.BoolValue(true),
+			errors:               1,
+		},
+		{
+			name:                 "primary with domains",
+			domains:              domains,
+			primary:              types.BoolValue(true),
+			searchDomainsEnabled: types.BoolNull(),
+			errors:               1,
+		},
+		{
+			name:                 "not primary without domains",
+			domains:              noDomains,
+			primary:              types.BoolValue(false),
+			searchDomainsEnabled: types.BoolNull(),
+			errors:               1,
+		},
+		{
+			name:                 "implicit primary",
+			domains:              types.ListNull(types.StringType),
+			primary:              types.BoolNull(),
+			searchDomainsEnabled: types.BoolNull(),
+		},
+		{
+			name:                 "match domains with search domains",
+			domains:              domains,
+			primary:              types.BoolNull(),
+			searchDomainsEnabled: types.BoolValue(true),
+		},
+		{
+			name:                 "unknown domains",
+			domains:              types.ListUnknown(types.StringType),
+			primary:              types.BoolValue(true),
+			searchDomainsEnabled: types.BoolNull(),
+		},
+	}
+
+	r := &NameserverGroup{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := testResourceState(t, r, &NameserverGroupModel{
+				Id:                   types.StringNull(),
+				Name:                 types.StringValue("ns"),
+				Description:          types.StringNull(),
+				Groups:               types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+				Domains:              c.domains,
+				Nameservers:          types.ListNull(types.ObjectType{AttrTypes: nsObjAttrs}),
+				Enabled:              types.BoolNull(),
+				Primary:              c.primary,
+				SearchDomainsEnabled: c.searchDomainsEnabled,
+			})
+
+			resp := tfresource.ValidateConfigResponse{}
+			for _, v := range r.ConfigValidators(context.Background()) {
+				v.ValidateResource(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+			}
+			if resp.Diagnostics.ErrorsCount() != c.errors {
+				t.Fatalf("Expected %d error diagnostics, found %v", c.errors, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func Test_nameserverValidation(t *testing.T) {
+	cases := []struct {
+		name   string
+		ip     string
+		nsType string
+		errors int
+	}{
+		{name: "udp with ipv4", ip: "8.8.8.8", nsType: "udp"},
+		{name: "udp with ipv6", ip: "2001:4860:4860::8888", nsType: "udp"},
+		{name: "doh with hostname", ip: "dns.google", nsType: "doh", errors: 2},
+		{name: "dot with ip", ip: "1.1.1.1", nsType: "dot", errors: 1},
+	}
+
+	r := &NameserverGroup{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	nameservers := schemaResp.Schema.Attributes["nameservers"].(schema.ListNestedAttribute).NestedObject.Attributes
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := validator.StringResponse{}
+			for _, v := range nameservers["ip"].(schema.StringAttribute).Validators {
+				v.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue(c.ip)}, &resp)
+			}
+			for _, v := range nameservers["ns_type"].(schema.StringAttribute).Validators {
+				v.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue(c.nsType)}, &resp)
+			}
+			if resp.Diagnostics.ErrorsCount() != c.errors {
+				t.Fatalf("Expected %d error diagnostics, found %v", c.errors, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
 func Test_fqdnRegex(t *testing.T) {
 	cases := []struct {
 		fqdn     string
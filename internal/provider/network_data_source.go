@@ -74,18 +74,18 @@ func (d *NetworkDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -105,7 +105,7 @@ func (d *NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	networks, err := d.client.Networks.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Networks", err.Error())
+		resp.Diagnostics.AddError("Error listing Networks", formatAPIError(err))
 		return
 	}
 
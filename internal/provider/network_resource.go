@@ -68,7 +68,7 @@ func (r *Network) Schema(ctx context.Context, req resource.SchemaRequest, resp *
 				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Network Description",
+				MarkdownDescription: "Network Description, an empty string when not set",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
@@ -99,18 +99,18 @@ func (r *Network) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func networkAPIToTerraform(ctx context.Context, network *api.Network, data *NetworkModel) diag.Diagnostics {
@@ -118,7 +118,7 @@ func networkAPIToTerraform(ctx context.Context, network *api.Network, data *Netw
 	var d diag.Diagnostics
 	data.Id = types.StringValue(network.Id)
 	data.Name = types.StringValue(network.Name)
-	data.Description = types.StringPointerValue(network.Description)
+	data.Description = normalizeDescription(network.Description)
 	data.Resources, d = types.ListValueFrom(ctx, types.StringType, network.Resources)
 	ret.Append(d...)
 	data.Routers, d = types.ListValueFrom(ctx, types.StringType, network.Routers)
@@ -145,7 +145,7 @@ func (r *Network) Create(ctx context.Context, req resource.CreateRequest, resp *
 
 	network, err := r.client.Networks.Create(ctx, networkReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating network", err.Error())
+		resp.Diagnostics.AddError("Error creating network", formatAPIError(err))
 		return
 	}
 
@@ -175,7 +175,7 @@ func (r *Network) Read(ctx context.Context, req resource.ReadRequest, resp *reso
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting Network", err.Error())
+			resp.Diagnostics.AddError("Error getting Network", formatAPIError(err))
 		}
 		return
 	}
@@ -212,7 +212,7 @@ func (r *Network) Update(ctx context.Context, req resource.UpdateRequest, resp *
 
 	network, err := r.client.Networks.Update(ctx, data.Id.ValueString(), networkReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Network", err.Error())
+		resp.Diagnostics.AddError("Error updating Network", formatAPIError(err))
 		return
 	}
 
@@ -236,8 +236,8 @@ func (r *Network) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 	}
 
 	err := r.client.Networks.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting Network", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Network", formatAPIError(err))
 	}
 }
 
@@ -76,18 +76,18 @@ func (d *NetworkResourceDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *NetworkResourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -107,7 +107,7 @@ func (d *NetworkResourceDataSource) Read(ctx context.Context, req datasource.Rea
 
 	networks, err := d.client.Networks.Resources(data.NetworkId.ValueString()).List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Network Resources", err.Error())
+		resp.Diagnostics.AddError("Error listing Network Resources", formatAPIError(err))
 		return
 	}
 
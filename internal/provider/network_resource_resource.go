@@ -6,6 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
@@ -75,16 +77,20 @@ func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "NetworkResource Description",
+				MarkdownDescription: "NetworkResource Description, an empty string when not set",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				Default:             stringdefault.StaticString(""),
 			},
 			"address": schema.StringAttribute{
-				MarkdownDescription: "Network resource address (either a direct host like 1.1.1.1 or 1.1.1.1/32, or a subnet like 192.168.178.0/24, or domains like example.com and *.example.com)",
+				MarkdownDescription: "Network resource address (either a direct host like 1.1.1.1 or 1.1.1.1/32, or a subnet like 192.168.178.0/24, or domains like example.com and *.example.com, wildcards are only allowed as the leftmost label and domains are sent in lowercase)",
 				Required:            true,
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(networkResourceAddressKindChanged, "Changing the kind of address (host, subnet or domain) requires replacement", "Changing the kind of address (host, subnet or domain) requires replacement"),
+				},
+				Validators: []validator.String{networkResourceAddressValidator{}},
 			},
 			"enabled": schema.BoolAttribute{
 				MarkdownDescription: "NetworkResource status",
@@ -108,18 +114,40 @@ func (r *NetworkResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+}
+
+// networkResourceAddressKind returns the network resource type the API assigns to an address, host, subnet or domain.
+func networkResourceAddressKind(address string) string {
+	if _, err := netip.ParseAddr(address); err == nil {
+		return "host"
+	}
+	if prefix, err := netip.ParsePrefix(address); err == nil {
+		if prefix.IsSingleIP() {
+			return "host"
+		}
+		return "subnet"
+	}
+	return "domain"
+}
+
+// networkResourceAddressKindChanged requires replacement when the address changes to another kind, edits within the same kind are applied in-place.
+func networkResourceAddressKindChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	resp.RequiresReplace = networkResourceAddressKind(req.StateValue.ValueString()) != networkResourceAddressKind(req.PlanValue.ValueString())
 }
 
 func networkResourceAPIToTerraform(ctx context.Context, networkResource *api.NetworkResource, data *NetworkResourceModel) diag.Diagnostics {
@@ -127,8 +155,10 @@ func networkResourceAPIToTerraform(ctx context.Context, networkResource *api.Net
 	var d diag.Diagnostics
 	data.Id = types.StringValue(networkResource.Id)
 	data.Name = types.StringValue(networkResource.Name)
-	data.Description = types.StringPointerValue(networkResource.Description)
-	data.Address = types.StringValue(networkResource.Address)
+	data.Description = normalizeDescription(networkResource.Description)
+	if !strings.EqualFold(data.Address.ValueString(), networkResource.Address) {
+		data.Address = types.StringValue(networkResource.Address)
+	}
 	data.Enabled = types.BoolValue(networkResource.Enabled)
 	groups := make([]string, len(networkResource.Groups))
 	for i, k := range networkResource.Groups {
@@ -152,14 +182,14 @@ func (r *NetworkResource) Create(ctx context.Context, req resource.CreateRequest
 	networkResourceReq := api.NetworkResourceRequest{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueStringPointer(),
-		Address:     data.Address.ValueString(),
+		Address:     normalizeNetworkResourceAddress(data.Address.ValueString()),
 		Enabled:     data.Enabled.ValueBool(),
 		Groups:      stringSetDefault(ctx, data.Groups, []string{}),
 	}
 
 	networkResource, err := r.client.Networks.Resources(data.NetworkId.ValueString()).Create(ctx, networkResourceReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating networkResource", err.Error())
+		resp.Diagnostics.AddError("Error creating networkResource", formatAPIError(err))
 		return
 	}
 
@@ -189,7 +219,7 @@ func (r *NetworkResource) Read(ctx context.Context, req resource.ReadRequest, re
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting NetworkResource", err.Error())
+			resp.Diagnostics.AddError("Error getting NetworkResource", formatAPIError(err))
 		}
 		return
 	}
@@ -222,14 +252,14 @@ func (r *NetworkResource) Update(ctx context.Context, req resource.UpdateRequest
 	networkResourceReq := api.NetworkResourceRequest{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueStringPointer(),
-		Address:     data.Address.ValueString(),
+		Address:     normalizeNetworkResourceAddress(data.Address.ValueString()),
 		Enabled:     data.Enabled.ValueBool(),
 		Groups:      stringSetDefault(ctx, data.Groups, []string{}),
 	}
 
 	networkResource, err := r.client.Networks.Resources(data.NetworkId.ValueString()).Update(ctx, data.Id.ValueString(), networkResourceReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating NetworkResource", err.Error())
+		resp.Diagnostics.AddError("Error updating NetworkResource", formatAPIError(err))
 		return
 	}
 
@@ -253,13 +283,37 @@ func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	err := r.client.Networks.Resources(data.NetworkId.ValueString()).Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting NetworkResource", err.Error())
+	// The network may have been deleted before its resources, which deletes them with it
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting NetworkResource", formatAPIError(err))
+	}
+}
+
+// networkResourceImportIDs describes the import IDs of the resources of network networkID.
+func networkResourceImportIDs(networkID string, networkResources []api.NetworkResource) string {
+	if len(networkResources) == 0 {
+		return fmt.Sprintf("Invalid import ID, must be in format `networkID/networkResourceID`, network %s has no resources", networkID)
 	}
+	ids := make([]string, len(networkResources))
+	for i, res := range networkResources {
+		ids[i] = fmt.Sprintf("  %s/%s (%s, %s)", networkID, res.Id, res.Name, res.Address)
+	}
+	slices.Sort(ids)
+	return fmt.Sprintf("Invalid import ID, must be in format `networkID/networkResourceID`, resources of network %s:\n%s", networkID, strings.Join(ids, "\n"))
 }
 
 func (r *NetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	splitID := strings.Split(req.ID, "/")
+	if len(splitID) == 1 && splitID[0] != "" {
+		// Only the network ID is given, list the resources that can be imported
+		networkResources, err := r.client.Networks.Resources(splitID[0]).List(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing NetworkResource", formatAPIError(err))
+			return
+		}
+		resp.Diagnostics.AddError("Error importing NetworkResource", networkResourceImportIDs(splitID[0], networkResources))
+		return
+	}
 	if len(splitID) != 2 {
 		resp.Diagnostics.AddError("Error importing NetworkResource", "Invalid import ID, must be in format `networkID/networkResourceID`")
 		return
@@ -2,14 +2,26 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/netbirdio/netbird/shared/management/http/api"
 )
@@ -32,7 +44,7 @@ func Test_networkResourceAPIToTerraform(t *testing.T) {
 			expected: NetworkResourceModel{
 				Id:          types.StringValue("r1"),
 				Name:        types.StringValue("test"),
-				Description: types.StringNull(),
+				Description: types.StringValue(""),
 				Address:     types.StringValue("1.1.1.1/32"),
 				Enabled:     types.BoolValue(false),
 				Groups:      types.SetValueMust(types.StringType, []attr.Value{}),
@@ -114,6 +126,228 @@ func Test_networkResourceAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_networkResourceAddressValidation(t *testing.T) {
+	cases := []struct {
+		address string
+		valid   bool
+	}{
+		{address: "1.1.1.1", valid: true},
+		{address: "192.168.178.0/24", valid: true},
+		{address: "2001:db8::/32", valid: true},
+		{address: "example.com", valid: true},
+		{address: "*.example.com", valid: true},
+		{address: "*.Example.COM", valid: true},
+		{address: "foo.*.com", valid: false},
+		{address: "**.com", valid: false},
+		{address: "*foo.example.com", valid: false},
+		{address: "example.*", valid: false},
+		{address: "*.", valid: false},
+		{address: "example..com", valid: false},
+	}
+
+	for _, c := range cases {
+		resp := validator.StringResponse{}
+		networkResourceAddressValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("address"),
+			ConfigValue: types.StringValue(c.address),
+		}, &resp)
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Fatalf("Expected valid=%t for %q, found %v", c.valid, c.address, resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func Test_networkResourceAddressNormalization(t *testing.T) {
+	if a := normalizeNetworkResourceAddress("*.Example.COM"); a != "*.example.com" {
+		t.Fatalf("Expected address *.example.com, found %s", a)
+	}
+
+	data := NetworkResourceModel{Address: types.StringValue("*.Example.COM"), Groups: types.SetNull(types.StringType)}
+	networkResourceAPIToTerraform(context.Background(), &api.NetworkResource{Address: "*.example.com"}, &data)
+	if data.Address.ValueString() != "*.Example.COM" {
+		t.Fatalf("Expected configured address *.Example.COM to be kept, found %s", data.Address.ValueString())
+	}
+
+	networkResourceAPIToTerraform(context.Background(), &api.NetworkResource{Address: "*.example.org"}, &data)
+	if data.Address.ValueString() != "*.example.org" {
+		t.Fatalf("Expected address *.example.org, found %s", data.Address.ValueString())
+	}
+}
+
+func Test_networkResourceAddressKindReplace(t *testing.T) {
+	cases := []struct {
+		state   string
+		plan    string
+		replace bool
+	}{
+		{state: "1.1.1.1", plan: "1.1.1.2/32", replace: false},
+		{state: "192.168.0.0/24", plan: "192.168.0.0/16", replace: false},
+		{state: "example.com", plan: "*.example.com", replace: false},
+		{state: "1.1.1.1", plan: "example.com", replace: true},
+		{state: "192.168.0.0/24", plan: "192.168.0.1", replace: true},
+		{state: "*.example.com", plan: "10.0.0.0/8", replace: true},
+	}
+
+	r := &NetworkResource{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		state := testResourceState(t, r, &NetworkResourceModel{
+			Id:          types.StringValue("r1"),
+			NetworkId:   types.StringValue("n1"),
+			Name:        types.StringValue("resource"),
+			Description: types.StringValue(""),
+			Address:     types.StringValue(c.state),
+			Enabled:     types.BoolValue(true),
+			Groups:      types.SetNull(types.StringType),
+		})
+		req := planmodifier.StringRequest{
+			Path:        path.Root("address"),
+			State:       state,
+			Plan:        tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+			StateValue:  types.StringValue(c.state),
+			PlanValue:   types.StringValue(c.plan),
+			ConfigValue: types.StringValue(c.plan),
+		}
+
+		resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+		for _, m := range schemaResp.Schema.Attributes["address"].(schema.StringAttribute).PlanModifiers {
+			m.PlanModifyString(context.Background(), req, &resp)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if resp.RequiresReplace != c.replace {
+			t.Fatalf("Expected replace=%t changing %s to %s, found %t", c.replace, c.state, c.plan, resp.RequiresReplace)
+		}
+	}
+}
+
+func Test_NetworkResource_ImportState(t *testing.T) {
+	cases := []struct {
+		id            string
+		networkID     string
+		resourceID    string
+		expectedError bool
+	}{
+		{id: "net123/res456", networkID: "net123", resourceID: "res456"},
+		{id: "res456", expectedError: true},
+		{id: "net123/res456/extra", expectedError: true},
+	}
+
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"network not found","code":404}`))
+	})
+	r := &NetworkResource{client: client}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		resp := tfresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}}
+		r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: c.id}, &resp)
+		if c.expectedError {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Error importing NetworkResource" {
+				t.Fatalf("Expected import of %q to fail, found %v", c.id, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var networkID, id types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("network_id"), &networkID)...)
+		resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+		if networkID.ValueString() != c.networkID || id.ValueString() != c.resourceID {
+			t.Fatalf("Expected import of %q to set network_id %s and id %s, found %s and %s", c.id, c.networkID, c.resourceID, networkID, id)
+		}
+	}
+}
+
+func Test_NetworkResource_ImportState_networkID(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected []string
+	}{
+		{
+			body: `[{"id":"res2","name":"db","address":"10.0.0.2/32","type":"host","enabled":true,"groups":[]},{"id":"res1","name":"web","address":"example.com","type":"domain","enabled":true,"groups":[]}]`,
+			expected: []string{
+				"net123/res1 (web, example.com)",
+				"net123/res2 (db, 10.0.0.2/32)",
+			},
+		},
+		{body: `[]`, expected: []string{"network net123 has no resources"}},
+	}
+
+	for _, c := range cases {
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/networks/net123/resources" {
+				t.Fatalf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(c.body))
+		})
+
+		r := &NetworkResource{client: client}
+		schemaResp := tfresource.SchemaResponse{}
+		r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+		resp := tfresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}}
+		r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: "net123"}, &resp)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Error importing NetworkResource" {
+			t.Fatalf("Expected import of a network ID to fail, found %v", resp.Diagnostics.Errors())
+		}
+		detail := resp.Diagnostics.Errors()[0].Detail()
+		for _, e := range c.expected {
+			if !strings.Contains(detail, e) {
+				t.Fatalf("Expected error to list %q, found %s", e, detail)
+			}
+		}
+	}
+}
+
+func Test_NetworkResource_Delete_networkDeleted(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		errors int
+	}{
+		{status: http.StatusOK, body: `{}`},
+		{status: http.StatusNotFound, body: `{"message":"network not found","code":404}`},
+		{status: http.StatusInternalServerError, body: `{"message":"internal error","code":500}`, errors: 1},
+	}
+
+	for _, c := range cases {
+		deletes := 0
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete && r.URL.Path == "/api/networks/network1/resources/r1" {
+				deletes++
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(c.status)
+			_, _ = w.Write([]byte(c.body))
+		})
+
+		r := &NetworkResource{client: client}
+		state := testResourceState(t, r, &NetworkResourceModel{
+			Id:        types.StringValue("r1"),
+			NetworkId: types.StringValue("network1"),
+			Name:      types.StringValue("test"),
+			Address:   types.StringValue("1.1.1.1/32"),
+			Enabled:   types.BoolValue(true),
+			Groups:    types.SetNull(types.StringType),
+		})
+		resp := tfresource.DeleteResponse{}
+		r.Delete(context.Background(), tfresource.DeleteRequest{State: state}, &resp)
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for status %d, found %v", c.errors, c.status, resp.Diagnostics.Errors())
+		}
+		if deletes != 1 {
+			t.Fatalf("Expected 1 delete call, found %d", deletes)
+		}
+	}
+}
+
 func Test_NetworkResource_Create(t *testing.T) {
 	rName := "nre" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_network_resource." + rName
@@ -204,6 +438,138 @@ func Test_NetworkResource_Update(t *testing.T) {
 	})
 }
 
+func Test_NetworkResource_Update_request(t *testing.T) {
+	var received api.NetworkResourceRequest
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/networks/network1/resources/r1" {
+			t.Fatalf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.NetworkResource{
+			Id:      "r1",
+			Name:    received.Name,
+			Address: received.Address,
+			Type:    api.NetworkResourceTypeHost,
+			Enabled: received.Enabled,
+			Groups:  []api.GroupMinimum{{Id: "g2"}, {Id: "g3"}},
+		})
+	})
+
+	r := &NetworkResource{client: client}
+	state := testResourceState(t, r, &NetworkResourceModel{
+		Id:        types.StringValue("r1"),
+		NetworkId: types.StringValue("network1"),
+		Name:      types.StringValue("test"),
+		Address:   types.StringValue("1.1.1.1/32"),
+		Enabled:   types.BoolValue(false),
+		Groups:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("g3"), types.StringValue("g2")}),
+	})
+	resp := tfresource.UpdateResponse{State: state}
+	r.Update(context.Background(), tfresource.UpdateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	slices.Sort(received.Groups)
+	if received.Enabled || !reflect.DeepEqual(received.Groups, []string{"g2", "g3"}) {
+		t.Fatalf("Expected update with enabled false and groups [g2 g3], found enabled %t and groups %v", received.Enabled, received.Groups)
+	}
+
+	var out NetworkResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+	if out.Enabled.ValueBool() || len(out.Groups.Elements()) != 2 {
+		t.Fatalf("Expected state with enabled false and 2 groups, found %s and %s", out.Enabled, out.Groups)
+	}
+}
+
+func Test_NetworkResource_Update_enabled(t *testing.T) {
+	rName := "nre" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_network_resource." + rName
+	var nreID string
+	checkResource := func(enabled bool, groups []string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			id := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+			if nreID == "" {
+				nreID = id
+			}
+			if id != nreID {
+				return fmt.Errorf("NetworkResource replaced, expected ID %s, found %s", nreID, id)
+			}
+			nre, err := testClient().Networks.Resources("network1").Get(context.Background(), id)
+			if err != nil {
+				return err
+			}
+			nreGroups := make([]string, len(nre.Groups))
+			for i, g := range nre.Groups {
+				nreGroups[i] = g.Id
+			}
+			slices.Sort(nreGroups)
+			if !slices.Equal(nreGroups, groups) {
+				return fmt.Errorf("NetworkResource Groups mismatch, expected %v, found %v on management server", groups, nreGroups)
+			}
+			return matchPairs(map[string][]any{
+				"enabled": {enabled, nre.Enabled},
+			})
+		}
+	}
+	updatePlanChecks := resource.ConfigPlanChecks{
+		PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(rNameFull, plancheck.ResourceActionUpdate)},
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: rName,
+				Config:       testNetworkResourceEnabledResource(rName, true, `["group-notall"]`),
+				Check:        checkResource(true, []string{"group-notall"}),
+			},
+			{
+				ResourceName:     rName,
+				Config:           testNetworkResourceEnabledResource(rName, false, `["group-all"]`),
+				ConfigPlanChecks: updatePlanChecks,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "enabled", "false"),
+					checkResource(false, []string{"group-all"}),
+				),
+			},
+			{
+				ResourceName:     rName,
+				Config:           testNetworkResourceEnabledResource(rName, true, `["group-notall", "group-all"]`),
+				ConfigPlanChecks: updatePlanChecks,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "enabled", "true"),
+					resource.TestCheckResourceAttr(rNameFull, "groups.#", "2"),
+					checkResource(true, []string{"group-all", "group-notall"}),
+				),
+			},
+			{
+				ResourceName: rName,
+				Config:       testNetworkResourceEnabledResource(rName, true, `["group-all", "group-notall"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func testNetworkResourceEnabledResource(rName string, enabled bool, groups string) string {
+	return fmt.Sprintf(`resource "netbird_network_resource" "%s" {
+	network_id = "network1"
+	address = "example.com"
+	groups = %s
+	name = "%s"
+	enabled = %t
+}`, rName, groups, rName, enabled)
+}
+
 func testNetworkResourceResource(rName, networkID, address, groups, name string) string {
 	return fmt.Sprintf(`resource "netbird_network_resource" "%s" {
 	network_id = "%s"
@@ -73,18 +73,18 @@ func (d *NetworkRouterDataSource) Configure(ctx context.Context, req datasource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *NetworkRouterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -100,7 +100,7 @@ func (d *NetworkRouterDataSource) Read(ctx context.Context, req datasource.ReadR
 	networkRouter, err := d.client.Networks.Routers(data.NetworkId.ValueString()).Get(ctx, data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error getting NetworkRouter", err.Error())
+		resp.Diagnostics.AddError("Error getting NetworkRouter", formatAPIError(err))
 		return
 	}
 
@@ -6,6 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
@@ -40,7 +42,7 @@ type NetworkRouter struct {
 	client *netbird.Client
 }
 
-// NetworkRouterModel describes the resource data model.
+// NetworkRouterModel describes the network router data model shared by the resource and data source.
 type NetworkRouterModel struct {
 	Id         types.String `tfsdk:"id"`
 	NetworkId  types.String `tfsdk:"network_id"`
@@ -51,6 +53,13 @@ type NetworkRouterModel struct {
 	Masquerade types.Bool   `tfsdk:"masquerade"`
 }
 
+// NetworkRouterResourceModel describes the resource data model.
+type NetworkRouterResourceModel struct {
+	NetworkRouterModel
+	EffectivePriority types.Int32 `tfsdk:"effective_priority"`
+	ValidatePeer      types.Bool  `tfsdk:"validate_peer"`
+}
+
 func (r *NetworkRouter) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_network_router"
 }
@@ -84,10 +93,10 @@ func (r *NetworkRouter) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:             booldefault.StaticBool(true),
 			},
 			"peer": schema.StringAttribute{
-				MarkdownDescription: "Peer Identifier associated with route. This property can not be set together with peer_groups",
+				MarkdownDescription: "Peer Identifier associated with route. Exactly one of peer or peer_groups must be set",
 				Optional:            true,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
-				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("peer_groups"))},
+				Validators:          []validator.String{stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("peer_groups"))},
 			},
 			"metric": schema.Int32Attribute{
 				MarkdownDescription: "Route metric number. Lowest number has higher priority",
@@ -97,12 +106,22 @@ func (r *NetworkRouter) Schema(ctx context.Context, req resource.SchemaRequest,
 				PlanModifiers:       []planmodifier.Int32{int32planmodifier.UseStateForUnknown()},
 				Validators:          []validator.Int32{int32validator.Between(1, 9999)},
 			},
+			"effective_priority": schema.Int32Attribute{
+				MarkdownDescription: "Position of the router among the enabled routers of the network ordered by metric, 1 is the highest priority, routers sharing a metric share a position. It depends on the metrics of the other routers, so it is recomputed on every update",
+				Computed:            true,
+			},
 			"peer_groups": schema.ListAttribute{
-				MarkdownDescription: "Peers Group Identifier associated with route. This property can not be set together with peer",
+				MarkdownDescription: "Peers Group Identifier associated with route. Exactly one of peer or peer_groups must be set",
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("peer"))},
 			},
+			"validate_peer": schema.BoolAttribute{
+				MarkdownDescription: "Check that peer exists and can act as a routing peer before creating or updating the router, requires an additional API call",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -113,18 +132,18 @@ func (r *NetworkRouter) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func networkRouterAPIToTerraform(ctx context.Context, networkRouter *api.NetworkRouter, data *NetworkRouterModel) diag.Diagnostics {
@@ -144,8 +163,68 @@ func networkRouterAPIToTerraform(ctx context.Context, networkRouter *api.Network
 	return ret
 }
 
+// networkRouterValidatePeer checks that the configured peer exists and can act as a routing peer when validate_peer is enabled.
+func networkRouterValidatePeer(ctx context.Context, client *netbird.Client, data NetworkRouterResourceModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	if !data.ValidatePeer.ValueBool() || data.Peer.IsNull() || data.Peer.IsUnknown() {
+		return ret
+	}
+
+	peer, err := client.Peers.Get(ctx, data.Peer.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			ret.AddAttributeError(path.Root("peer"), "Peer Not Found", fmt.Sprintf("Peer %q does not exist, peer must be the ID of an existing peer", data.Peer.ValueString()))
+		} else {
+			ret.AddError("Error getting Peer", formatAPIError(err))
+		}
+		return ret
+	}
+
+	// Peers running with server routes disabled ignore the routes they are assigned
+	if peer.LocalFlags != nil && peer.LocalFlags.DisableServerRoutes != nil && *peer.LocalFlags.DisableServerRoutes {
+		ret.AddAttributeError(path.Root("peer"), "Peer Cannot Route", fmt.Sprintf("Peer %q (%s) has server routes disabled and cannot act as a routing peer, run its NetBird client without --disable-server-routes or choose another peer", data.Peer.ValueString(), peer.Name))
+	}
+	return ret
+}
+
+// networkRouterPriority sets the effective priority of the router among the
+// enabled routers of its network, and warns about routers sharing its metric.
+// Failing to list the routers is a warning, the priority is left as planned.
+func networkRouterPriority(ctx context.Context, client *netbird.Client, data *NetworkRouterResourceModel, warn bool) diag.Diagnostics {
+	var ret diag.Diagnostics
+	routers, err := client.Networks.Routers(data.NetworkId.ValueString()).List(ctx)
+	if err != nil {
+		if data.EffectivePriority.IsUnknown() {
+			data.EffectivePriority = types.Int32Null()
+		}
+		ret.AddAttributeWarning(path.Root("effective_priority"), "Error listing NetworkRouters", formatAPIError(err))
+		return ret
+	}
+
+	metric := int(data.Metric.ValueInt32())
+	priority := int32(1)
+	var collisions []string
+	for _, router := range routers {
+		if router.Id == data.Id.ValueString() || !router.Enabled {
+			continue
+		}
+		if router.Metric < metric {
+			priority++
+		}
+		if router.Metric == metric {
+			collisions = append(collisions, strconv.Quote(router.Id))
+		}
+	}
+	data.EffectivePriority = types.Int32Value(priority)
+
+	if warn && len(collisions) > 0 {
+		ret.AddAttributeWarning(path.Root("metric"), "Duplicate Router Metric", fmt.Sprintf("Routers %s in network %q use the same metric %d, set distinct metrics to make the order of routers deterministic", strings.Join(collisions, ", "), data.NetworkId.ValueString(), metric))
+	}
+	return ret
+}
+
 func (r *NetworkRouter) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data NetworkRouterModel
+	var data NetworkRouterResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -154,6 +233,11 @@ func (r *NetworkRouter) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(networkRouterValidatePeer(ctx, r.client, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	networkRouterReq := api.NetworkRouterRequest{
 		Enabled:    data.Enabled.ValueBool(),
 		Masquerade: data.Masquerade.ValueBool(),
@@ -164,22 +248,19 @@ func (r *NetworkRouter) Create(ctx context.Context, req resource.CreateRequest,
 
 	networkRouter, err := r.client.Networks.Routers(data.NetworkId.ValueString()).Create(ctx, networkRouterReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating networkRouter", err.Error())
+		resp.Diagnostics.AddError("Error creating networkRouter", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(networkRouterAPIToTerraform(ctx, networkRouter, &data)...)
+	resp.Diagnostics.Append(networkRouterAPIToTerraform(ctx, networkRouter, &data.NetworkRouterModel)...)
+	resp.Diagnostics.Append(networkRouterPriority(ctx, r.client, &data, true)...)
 
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// Save data into Terraform state
+	// Save data into Terraform state, the router exists even if its priority is unknown
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *NetworkRouter) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data NetworkRouterModel
+	var data NetworkRouterResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -194,12 +275,13 @@ func (r *NetworkRouter) Read(ctx context.Context, req resource.ReadRequest, resp
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting NetworkRouter", err.Error())
+			resp.Diagnostics.AddError("Error getting NetworkRouter", formatAPIError(err))
 		}
 		return
 	}
 
-	resp.Diagnostics.Append(networkRouterAPIToTerraform(ctx, networkRouter, &data)...)
+	resp.Diagnostics.Append(networkRouterAPIToTerraform(ctx, networkRouter, &data.NetworkRouterModel)...)
+	resp.Diagnostics.Append(networkRouterPriority(ctx, r.client, &data, false)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -210,7 +292,7 @@ func (r *NetworkRouter) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *NetworkRouter) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data NetworkRouterModel
+	var data NetworkRouterResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -224,6 +306,11 @@ func (r *NetworkRouter) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(networkRouterValidatePeer(ctx, r.client, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	networkRouterReq := api.NetworkRouterRequest{
 		Enabled:    data.Enabled.ValueBool(),
 		Masquerade: data.Masquerade.ValueBool(),
@@ -234,21 +321,19 @@ func (r *NetworkRouter) Update(ctx context.Context, req resource.UpdateRequest,
 
 	networkRouter, err := r.client.Networks.Routers(data.NetworkId.ValueString()).Update(ctx, data.Id.ValueString(), networkRouterReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating NetworkRouter", err.Error())
+		resp.Diagnostics.AddError("Error updating NetworkRouter", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(networkRouterAPIToTerraform(ctx, networkRouter, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(networkRouterAPIToTerraform(ctx, networkRouter, &data.NetworkRouterModel)...)
+	resp.Diagnostics.Append(networkRouterPriority(ctx, r.client, &data, true)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *NetworkRouter) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data NetworkRouterModel
+	var data NetworkRouterResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -258,17 +343,48 @@ func (r *NetworkRouter) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	err := r.client.Networks.Routers(data.NetworkId.ValueString()).Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting NetworkRouter", err.Error())
+	// The network may have been deleted before its routers, which deletes them with it
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting NetworkRouter", formatAPIError(err))
+	}
+}
+
+// networkRouterImportIDs describes the import IDs of the routers of network networkID.
+func networkRouterImportIDs(networkID string, routers []api.NetworkRouter) string {
+	if len(routers) == 0 {
+		return fmt.Sprintf("Invalid import ID, must be in format `networkID/networkRouterID`, network %s has no routers", networkID)
+	}
+	ids := make([]string, len(routers))
+	for i, router := range routers {
+		routingPeers := "no routing peer"
+		if router.Peer != nil {
+			routingPeers = "peer " + *router.Peer
+		} else if router.PeerGroups != nil {
+			routingPeers = "peer groups " + strings.Join(*router.PeerGroups, ", ")
+		}
+		ids[i] = fmt.Sprintf("  %s/%s (%s)", networkID, router.Id, routingPeers)
 	}
+	slices.Sort(ids)
+	return fmt.Sprintf("Invalid import ID, must be in format `networkID/networkRouterID`, routers of network %s:\n%s", networkID, strings.Join(ids, "\n"))
 }
 
 func (r *NetworkRouter) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	splitID := strings.Split(req.ID, "/")
+	if len(splitID) == 1 && splitID[0] != "" {
+		// Only the network ID is given, list the routers that can be imported
+		routers, err := r.client.Networks.Routers(splitID[0]).List(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing NetworkRouter", formatAPIError(err))
+			return
+		}
+		resp.Diagnostics.AddError("Error importing NetworkRouter", networkRouterImportIDs(splitID[0], routers))
+		return
+	}
 	if len(splitID) != 2 {
 		resp.Diagnostics.AddError("Error importing NetworkRouter", "Invalid import ID, must be in format `networkID/networkRouterID`")
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), splitID[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), splitID[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("validate_peer"), false)...)
 }
@@ -2,14 +2,24 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/netbirdio/netbird/shared/management/http/api"
 )
@@ -88,6 +98,328 @@ func Test_networkRouterAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_NetworkRouter_Create_validatePeer(t *testing.T) {
+	creates := 0
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			creates++
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"peer not found","code":404}`))
+	})
+
+	r := &NetworkRouter{client: client}
+	plan := testResourceState(t, r, &NetworkRouterResourceModel{
+		NetworkRouterModel: NetworkRouterModel{
+			NetworkId:  types.StringValue("network1"),
+			Enabled:    types.BoolValue(true),
+			Masquerade: types.BoolValue(true),
+			Metric:     types.Int32Value(9999),
+			Peer:       types.StringValue("nonexistent"),
+			PeerGroups: types.ListNull(types.StringType),
+		},
+		ValidatePeer: types.BoolValue(true),
+	})
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Peer Not Found" {
+		t.Fatalf("Expected Peer Not Found error, found %v", resp.Diagnostics.Errors())
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), `"nonexistent"`) {
+		t.Fatalf("Expected error to name the missing peer, found %s", resp.Diagnostics.Errors()[0].Detail())
+	}
+	if creates != 0 {
+		t.Fatalf("Expected no create request, found %d", creates)
+	}
+}
+
+func Test_NetworkRouter_Create_validatePeerRouting(t *testing.T) {
+	cases := []struct {
+		disableServerRoutes *bool
+		expectedError       string
+	}{
+		{disableServerRoutes: nil},
+		{disableServerRoutes: valPtr(false)},
+		{disableServerRoutes: valPtr(true), expectedError: "Peer Cannot Route"},
+	}
+
+	for _, c := range cases {
+		creates := 0
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/peers/p1":
+				_ = json.NewEncoder(w).Encode(api.Peer{Id: "p1", Name: "mobile", LocalFlags: &api.PeerLocalFlags{DisableServerRoutes: c.disableServerRoutes}})
+			case r.Method == http.MethodPost:
+				creates++
+				_ = json.NewEncoder(w).Encode(api.NetworkRouter{Id: "r1", Enabled: true, Masquerade: true, Metric: 9999, Peer: valPtr("p1")})
+			default:
+				_ = json.NewEncoder(w).Encode([]api.NetworkRouter{})
+			}
+		})
+
+		r := &NetworkRouter{client: client}
+		plan := testResourceState(t, r, &NetworkRouterResourceModel{
+			NetworkRouterModel: NetworkRouterModel{
+				NetworkId:  types.StringValue("network1"),
+				Enabled:    types.BoolValue(true),
+				Masquerade: types.BoolValue(true),
+				Metric:     types.Int32Value(9999),
+				Peer:       types.StringValue("p1"),
+				PeerGroups: types.ListNull(types.StringType),
+			},
+			ValidatePeer: types.BoolValue(true),
+		})
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+		r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+		if c.expectedError == "" {
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			if creates != 1 {
+				t.Fatalf("Expected a create request, found %d", creates)
+			}
+			continue
+		}
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+			t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+		}
+		if creates != 0 {
+			t.Fatalf("Expected no create request, found %d", creates)
+		}
+	}
+}
+
+func Test_networkRouterPeerValidation(t *testing.T) {
+	cases := []struct {
+		name       string
+		peer       types.String
+		peerGroups types.List
+		valid      bool
+	}{
+		{name: "peer", peer: types.StringValue("p1"), peerGroups: types.ListNull(types.StringType), valid: true},
+		{name: "peer groups", peer: types.StringNull(), peerGroups: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}), valid: true},
+		{name: "both", peer: types.StringValue("p1"), peerGroups: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}), valid: false},
+		{name: "neither", peer: types.StringNull(), peerGroups: types.ListNull(types.StringType), valid: false},
+	}
+
+	r := &NetworkRouter{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := testResourceState(t, r, &NetworkRouterResourceModel{
+				NetworkRouterModel: NetworkRouterModel{
+					NetworkId:  types.StringValue("network1"),
+					Peer:       c.peer,
+					PeerGroups: c.peerGroups,
+				},
+			})
+			req := validator.StringRequest{
+				Path:           path.Root("peer"),
+				PathExpression: path.MatchRoot("peer"),
+				Config:         tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+				ConfigValue:    c.peer,
+			}
+			resp := validator.StringResponse{}
+			for _, v := range schemaResp.Schema.Attributes["peer"].(schema.StringAttribute).Validators {
+				v.ValidateString(context.Background(), req, &resp)
+			}
+			if resp.Diagnostics.HasError() == c.valid {
+				t.Fatalf("Expected valid=%t, found %v", c.valid, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func Test_NetworkRouter_Create_metricCollision(t *testing.T) {
+	cases := []struct {
+		metric           int32
+		expectedPriority int32
+		expectedWarnings int
+	}{
+		{metric: 9999, expectedPriority: 2, expectedWarnings: 1},
+		{metric: 100, expectedPriority: 1, expectedWarnings: 1},
+		{metric: 50, expectedPriority: 1, expectedWarnings: 0},
+	}
+
+	for _, c := range cases {
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPost {
+				_ = json.NewEncoder(w).Encode(api.NetworkRouter{Id: "ro3", Enabled: true, Masquerade: true, Metric: int(c.metric), Peer: valPtr("peer3")})
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]api.NetworkRouter{
+				{Id: "ro1", Enabled: true, Metric: 100},
+				{Id: "ro2", Enabled: true, Metric: 9999},
+				{Id: "ro3", Enabled: true, Metric: int(c.metric)},
+				{Id: "ro4", Enabled: false, Metric: 50},
+			})
+		})
+
+		r := &NetworkRouter{client: client}
+		plan := testResourceState(t, r, &NetworkRouterResourceModel{
+			NetworkRouterModel: NetworkRouterModel{
+				NetworkId:  types.StringValue("network1"),
+				Enabled:    types.BoolValue(true),
+				Masquerade: types.BoolValue(true),
+				Metric:     types.Int32Value(c.metric),
+				Peer:       types.StringValue("peer3"),
+				PeerGroups: types.ListNull(types.StringType),
+			},
+			EffectivePriority: types.Int32Unknown(),
+			ValidatePeer:      types.BoolValue(false),
+		})
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+		r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if resp.Diagnostics.WarningsCount() != c.expectedWarnings {
+			t.Fatalf("Expected %d warnings for metric %d, found %v", c.expectedWarnings, c.metric, resp.Diagnostics.Warnings())
+		}
+		var priority types.Int32
+		resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("effective_priority"), &priority)...)
+		if priority.ValueInt32() != c.expectedPriority {
+			t.Fatalf("Expected effective_priority %d for metric %d, found %s", c.expectedPriority, c.metric, priority)
+		}
+	}
+}
+
+func Test_NetworkRouter_Create_listError(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			_ = json.NewEncoder(w).Encode(api.NetworkRouter{Id: "ro1", Enabled: true, Masquerade: true, Metric: 100, Peer: valPtr("peer1")})
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message":"internal error","code":500}`))
+	})
+
+	r := &NetworkRouter{client: client}
+	plan := testResourceState(t, r, &NetworkRouterResourceModel{
+		NetworkRouterModel: NetworkRouterModel{
+			NetworkId:  types.StringValue("network1"),
+			Enabled:    types.BoolValue(true),
+			Masquerade: types.BoolValue(true),
+			Metric:     types.Int32Value(100),
+			Peer:       types.StringValue("peer1"),
+			PeerGroups: types.ListNull(types.StringType),
+		},
+		EffectivePriority: types.Int32Unknown(),
+		ValidatePeer:      types.BoolValue(false),
+	})
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected 1 warning, found %v", resp.Diagnostics.Warnings())
+	}
+	var data NetworkRouterResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	if data.Id.ValueString() != "ro1" {
+		t.Fatalf("Expected router ro1 to be saved in state, found %s", data.Id)
+	}
+	if !data.EffectivePriority.IsNull() {
+		t.Fatalf("Expected null effective_priority, found %s", data.EffectivePriority)
+	}
+}
+
+func Test_NetworkRouter_ImportState_networkID(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected []string
+	}{
+		{
+			body: `[{"id":"r2","peer_groups":["g1","g2"],"metric":9999,"masquerade":true,"enabled":true},{"id":"r1","peer":"p1","metric":9999,"masquerade":true,"enabled":true}]`,
+			expected: []string{
+				"net123/r1 (peer p1)",
+				"net123/r2 (peer groups g1, g2)",
+			},
+		},
+		{body: `[]`, expected: []string{"network net123 has no routers"}},
+	}
+
+	for _, c := range cases {
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/networks/net123/routers" {
+				t.Fatalf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(c.body))
+		})
+
+		r := &NetworkRouter{client: client}
+		schemaResp := tfresource.SchemaResponse{}
+		r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+		resp := tfresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}}
+		r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: "net123"}, &resp)
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Error importing NetworkRouter" {
+			t.Fatalf("Expected import of a network ID to fail, found %v", resp.Diagnostics.Errors())
+		}
+		detail := resp.Diagnostics.Errors()[0].Detail()
+		for _, e := range c.expected {
+			if !strings.Contains(detail, e) {
+				t.Fatalf("Expected error to list %q, found %s", e, detail)
+			}
+		}
+	}
+}
+
+func Test_NetworkRouter_Delete_networkDeleted(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		errors int
+	}{
+		{status: http.StatusOK, body: `{}`},
+		{status: http.StatusNotFound, body: `{"message":"network not found","code":404}`},
+		{status: http.StatusInternalServerError, body: `{"message":"internal error","code":500}`, errors: 1},
+	}
+
+	for _, c := range cases {
+		deletes := 0
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete && r.URL.Path == "/api/networks/network1/routers/r1" {
+				deletes++
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(c.status)
+			_, _ = w.Write([]byte(c.body))
+		})
+
+		r := &NetworkRouter{client: client}
+		state := testResourceState(t, r, &NetworkRouterResourceModel{
+			NetworkRouterModel: NetworkRouterModel{
+				Id:         types.StringValue("r1"),
+				NetworkId:  types.StringValue("network1"),
+				Enabled:    types.BoolValue(true),
+				Masquerade: types.BoolValue(true),
+				Metric:     types.Int32Value(9999),
+				PeerGroups: types.ListNull(types.StringType),
+			},
+			ValidatePeer: types.BoolValue(false),
+		})
+		resp := tfresource.DeleteResponse{}
+		r.Delete(context.Background(), tfresource.DeleteRequest{State: state}, &resp)
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for status %d, found %v", c.errors, c.status, resp.Diagnostics.Errors())
+		}
+		if deletes != 1 {
+			t.Fatalf("Expected 1 delete call, found %d", deletes)
+		}
+	}
+}
+
 func Test_NetworkRouter_Create(t *testing.T) {
 	rName := "nro" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_network_router." + rName
@@ -169,6 +501,85 @@ func Test_NetworkRouter_Update(t *testing.T) {
 	})
 }
 
+func Test_NetworkRouter_Update_toggles(t *testing.T) {
+	rName := "nro" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_network_router." + rName
+	var routerID string
+	checkRouter := func(enabled, masquerade bool, peer string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			nroID := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+			router, err := testClient().Networks.Routers("network1").Get(context.Background(), nroID)
+			if err != nil {
+				return err
+			}
+			if routerID == "" {
+				routerID = nroID
+			}
+			routerPeer := ""
+			if router.Peer != nil {
+				routerPeer = *router.Peer
+			}
+			return matchPairs(map[string][]any{
+				"id":         {routerID, router.Id},
+				"enabled":    {enabled, router.Enabled},
+				"masquerade": {masquerade, router.Masquerade},
+				"peer":       {peer, routerPeer},
+			})
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: rName,
+				Config:       testNetworkRouterTogglesResource(rName, `peer_groups = ["group-notall"]`, true, true),
+				Check:        checkRouter(true, true, ""),
+			},
+			{
+				ResourceName: rName,
+				Config:       testNetworkRouterTogglesResource(rName, `peer_groups = ["group-notall"]`, false, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(rNameFull, plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "enabled", "false"),
+					resource.TestCheckResourceAttr(rNameFull, "masquerade", "false"),
+					checkRouter(false, false, ""),
+				),
+			},
+			{
+				ResourceName: rName,
+				Config:       testNetworkRouterTogglesResource(rName, `peer = "peer1"`, true, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(rNameFull, plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "peer", "peer1"),
+					resource.TestCheckNoResourceAttr(rNameFull, "peer_groups"),
+					checkRouter(true, false, "peer1"),
+				),
+			},
+			{
+				ResourceName: rName,
+				Config:       testNetworkRouterTogglesResource(rName, `peer = "peer1"`, true, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func testNetworkRouterTogglesResource(rName, routingPeers string, enabled, masquerade bool) string {
+	return fmt.Sprintf(`resource "netbird_network_router" "%s" {
+  network_id = "network1"
+  %s
+  enabled    = %t
+  masquerade = %t
+}`, rName, routingPeers, enabled, masquerade)
+}
+
 func testNetworkRouterResource(rName, networkID, peerGroup string) string {
 	return fmt.Sprintf(`resource "netbird_network_router" "%s" {
 	network_id = "%s"
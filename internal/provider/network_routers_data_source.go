@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NetworkRoutersDataSource{}
+
+func NewNetworkRoutersDataSource() datasource.DataSource {
+	return &NetworkRoutersDataSource{}
+}
+
+// NetworkRoutersModel describes the data source data model.
+type NetworkRoutersModel struct {
+	Ids        types.List   `tfsdk:"ids"`
+	NetworkId  types.String `tfsdk:"network_id"`
+	Peer       types.String `tfsdk:"peer"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Metric     types.Int32  `tfsdk:"metric"`
+	Masquerade types.Bool   `tfsdk:"masquerade"`
+}
+
+// NetworkRoutersDataSource defines the data source implementation.
+type NetworkRoutersDataSource struct {
+	client *netbird.Client
+}
+
+func (d *NetworkRoutersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_routers"
+}
+
+func (d *NetworkRoutersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Read Network Routers of a Network",
+		MarkdownDescription: "Read Network Routers of a Network, see [NetBird Docs](https://docs.netbird.io/how-to/networks#routing-peers) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "Network Router IDs",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of a network",
+				Required:            true,
+			},
+			"peer": schema.StringAttribute{
+				MarkdownDescription: "Peer Identifier associated with route",
+				Optional:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Network router status",
+				Optional:            true,
+			},
+			"metric": schema.Int32Attribute{
+				MarkdownDescription: "Route metric number",
+				Optional:            true,
+			},
+			"masquerade": schema.BoolAttribute{
+				MarkdownDescription: "Indicate if peer should masquerade traffic to this route's prefix",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (d *NetworkRoutersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func filterNetworkRouters(routers []api.NetworkRouter, data NetworkRoutersModel) []string {
+	filteredRouters := []string{}
+	for _, r := range routers {
+		peer := ""
+		if r.Peer != nil {
+			peer = *r.Peer
+		}
+		match := 0
+		match += matchString(peer, data.Peer)
+		match += matchBool(r.Enabled, data.Enabled)
+		match += matchInt32(int32(r.Metric), data.Metric)
+		match += matchBool(r.Masquerade, data.Masquerade)
+
+		// Without any selector all routers of the network are included
+		if match >= 0 {
+			filteredRouters = append(filteredRouters, r.Id)
+		}
+	}
+
+	return filteredRouters
+}
+
+func (d *NetworkRoutersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NetworkRoutersModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routers, err := d.client.Networks.Routers(data.NetworkId.ValueString()).List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing NetworkRouters", formatAPIError(err))
+		return
+	}
+
+	ids, di := types.ListValueFrom(ctx, types.StringType, filterNetworkRouters(routers, data))
+	resp.Diagnostics.Append(di...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Ids = ids
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+func Test_filterNetworkRouters(t *testing.T) {
+	routers := []api.NetworkRouter{
+		{
+			Id:         "r1",
+			Enabled:    true,
+			Masquerade: true,
+			Metric:     9999,
+			Peer:       valPtr("peer1"),
+		},
+		{
+			Id:         "r2",
+			Enabled:    false,
+			Masquerade: true,
+			Metric:     100,
+			PeerGroups: &[]string{"g1"},
+		},
+		{
+			Id:         "r3",
+			Enabled:    true,
+			Masquerade: false,
+			Metric:     100,
+			Peer:       valPtr("peer2"),
+		},
+	}
+
+	cases := []struct {
+		filter   NetworkRoutersModel
+		expected []string
+	}{
+		{
+			filter:   NetworkRoutersModel{},
+			expected: []string{"r1", "r2", "r3"},
+		},
+		{
+			filter:   NetworkRoutersModel{Peer: types.StringValue("peer1")},
+			expected: []string{"r1"},
+		},
+		{
+			filter:   NetworkRoutersModel{Enabled: types.BoolValue(true)},
+			expected: []string{"r1", "r3"},
+		},
+		{
+			filter:   NetworkRoutersModel{Metric: types.Int32Value(100), Masquerade: types.BoolValue(true)},
+			expected: []string{"r2"},
+		},
+		{
+			filter:   NetworkRoutersModel{Peer: types.StringValue("peer3")},
+			expected: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		out := filterNetworkRouters(routers, c.filter)
+		if !slices.Equal(out, c.expected) {
+			t.Fatalf("Expected:\n%#v\nFound:\n%#v", c.expected, out)
+		}
+	}
+}
+
+func Test_NetworkRouters_Read(t *testing.T) {
+	rName := "nro" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_network_router." + rName
+	dNameFull := "data.netbird_network_routers." + rName
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: rName,
+				Config: testNetworkRouterResource(rName, "network1", `["group-notall"]`) + fmt.Sprintf(`
+data "netbird_network_routers" "%s" {
+	network_id = "network1"
+	metric     = netbird_network_router.%s.metric
+}`, rName, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dNameFull, "ids.#"),
+					resource.TestCheckTypeSetElemAttrPair(dNameFull, "ids.*", rNameFull, "id"),
+				),
+			},
+		},
+	})
+}
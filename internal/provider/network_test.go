@@ -3,10 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -57,6 +60,23 @@ func Test_networkAPIToTerraform(t *testing.T) {
 				Policies:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("p1")}),
 			},
 		},
+		{
+			resource: &api.Network{
+				Id:        "n3",
+				Name:      "Network3",
+				Policies:  []string{},
+				Resources: []string{},
+				Routers:   []string{},
+			},
+			expected: NetworkModel{
+				Id:          types.StringValue("n3"),
+				Name:        types.StringValue("Network3"),
+				Description: types.StringValue(""),
+				Resources:   types.ListValueMust(types.StringType, []attr.Value{}),
+				Routers:     types.ListValueMust(types.StringType, []attr.Value{}),
+				Policies:    types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -72,6 +92,30 @@ func Test_networkAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_Network_Create_apiError(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"network name must not be empty","code":422}`))
+	})
+
+	r := &Network{client: client}
+	state := testResourceState(t, r, &NetworkModel{
+		Name:      types.StringValue(" "),
+		Resources: types.ListNull(types.StringType),
+		Routers:   types.ListNull(types.StringType),
+		Policies:  types.ListNull(types.StringType),
+	})
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: state.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("Expected 1 error, found %v", resp.Diagnostics.Errors())
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); detail != "network name must not be empty (HTTP 422 Unprocessable Entity)" {
+		t.Fatalf("Expected the API error message and status in the diagnostic, found %q", detail)
+	}
+}
+
 func Test_Network_Create(t *testing.T) {
 	rName := "n" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_network." + rName
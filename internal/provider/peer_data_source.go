@@ -6,9 +6,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
 	"github.com/netbirdio/netbird/shared/management/http/api"
@@ -26,13 +31,19 @@ type PeerDataSource struct {
 	client *netbird.Client
 }
 
+// PeerDataSourceModel describes the data source data model.
+type PeerDataSourceModel struct {
+	PeerModel
+	HostnamePrefix types.String `tfsdk:"hostname_prefix"`
+}
+
 func (d *PeerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_peer"
 }
 
 func (d *PeerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Read Peer information.",
+		MarkdownDescription: "Read Peer information. All of `id`, `name`, `ip`, `hostname` and `serial_number` that are set must match the peer exactly, `hostname_prefix` additionally matches peers whose hostname starts with it, preferring a peer whose hostname equals it. Matching more than one peer is an error.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Peer ID",
@@ -58,7 +69,7 @@ func (d *PeerDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 			},
 			"last_seen": schema.StringAttribute{
-				MarkdownDescription: "Peer Last Seen timedate",
+				MarkdownDescription: "Peer Last Seen timedate, null if the peer was never seen",
 				Computed:            true,
 			},
 			"os": schema.StringAttribute{
@@ -82,6 +93,11 @@ func (d *PeerDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"group_names": schema.ListAttribute{
+				MarkdownDescription: "Peer group names, in the same order as `groups`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 			"ssh_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Enable SSH to Peer",
 				Computed:            true,
@@ -104,8 +120,14 @@ func (d *PeerDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 			},
 			"hostname": schema.StringAttribute{
 				MarkdownDescription: "Peer's HOSTNAME",
+				Optional:            true,
 				Computed:            true,
 			},
+			"hostname_prefix": schema.StringAttribute{
+				MarkdownDescription: "Match peers whose hostname starts with this value, for hostnames with domain suffixes appended, a peer whose hostname equals it exactly takes precedence",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1), stringvalidator.ConflictsWith(path.MatchRoot("hostname"))},
+			},
 			"ui_version": schema.StringAttribute{
 				MarkdownDescription: "Peer  UI Version",
 				Computed:            true,
@@ -119,7 +141,7 @@ func (d *PeerDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 			},
 			"last_login": schema.StringAttribute{
-				MarkdownDescription: "Time of peer last login",
+				MarkdownDescription: "Time of peer last login, null if the peer never logged in",
 				Computed:            true,
 			},
 			"country_code": schema.StringAttribute{
@@ -132,6 +154,12 @@ func (d *PeerDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 			},
 			"serial_number": schema.StringAttribute{
 				MarkdownDescription: "Peer device serial number",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"ephemeral": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether the peer was registered with an ephemeral setup key and is removed automatically after being offline",
 				Computed:            true,
 			},
 			"extra_dns_labels": schema.ListAttribute{
@@ -149,22 +177,22 @@ func (d *PeerDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *PeerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data PeerModel
+	var data PeerDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -173,36 +201,19 @@ func (d *PeerDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	var peer *api.Peer
 	peers, err := d.client.Peers.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Peers", err.Error())
+		resp.Diagnostics.AddError("Error listing Peers", formatAPIError(err))
 		return
 	}
 
-	for _, p := range peers {
-		match := 0
-		match += matchString(p.Id, data.Id)
-		match += matchString(p.Name, data.Name)
-		match += matchString(p.Ip, data.Ip)
-		if match > 0 {
-			if peer != nil {
-				resp.Diagnostics.AddError("Multiple Matches", "data source cannot match multiple peers")
-			}
-			peer = &p
-		}
-	}
-
+	peer, diags := peerDataSourceMatch(peers, data)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if peer == nil {
-		resp.Diagnostics.AddError("Not Found", "Peer not found")
-		return
-	}
-
-	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data)...)
+	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data.PeerModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -211,3 +222,51 @@ func (d *PeerDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// peerDataSourceMatch returns the single peer matching every set lookup attribute, hostname_prefix matches
+// hostnames starting with it and an exact hostname match takes precedence over prefix matches.
+func peerDataSourceMatch(peers []api.Peer, data PeerDataSourceModel) (*api.Peer, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	prefix := data.HostnamePrefix.ValueString()
+
+	var matches, exact []*api.Peer
+	for i, p := range peers {
+		match := 0
+		match += matchString(p.Id, data.Id)
+		match += matchString(p.Name, data.Name)
+		match += matchString(p.Ip, data.Ip)
+		match += matchString(p.Hostname, data.Hostname)
+		match += matchString(p.SerialNumber, data.SerialNumber)
+		if prefix != "" {
+			if match < 0 || !strings.HasPrefix(p.Hostname, prefix) {
+				continue
+			}
+			if p.Hostname == prefix {
+				exact = append(exact, &peers[i])
+			}
+		} else if match <= 0 {
+			continue
+		}
+		matches = append(matches, &peers[i])
+	}
+	if len(exact) > 0 {
+		matches = exact
+	}
+
+	switch {
+	case len(matches) == 0:
+		ret.AddError("Not Found", "Peer not found")
+		return nil, ret
+	case len(matches) > 1 && prefix != "":
+		ids := make([]string, 0, len(matches))
+		for _, p := range matches {
+			ids = append(ids, p.Id)
+		}
+		ret.AddAttributeError(path.Root("hostname_prefix"), "Multiple Matches", fmt.Sprintf("hostname_prefix %q matches multiple peers: %s", prefix, strings.Join(ids, ", ")))
+		return nil, ret
+	case len(matches) > 1:
+		ret.AddError("Multiple Matches", "data source cannot match multiple peers")
+		return nil, ret
+	}
+	return matches[0], ret
+}
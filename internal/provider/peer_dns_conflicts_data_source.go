@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PeerDNSConflictsDataSource{}
+
+func NewPeerDNSConflictsDataSource() datasource.DataSource {
+	return &PeerDNSConflictsDataSource{}
+}
+
+// PeerDNSConflictsDataSource defines the data source implementation.
+type PeerDNSConflictsDataSource struct {
+	client *netbird.Client
+}
+
+// PeerDNSConflictsModel describes the data source data model.
+type PeerDNSConflictsModel struct {
+	Conflicts types.List `tfsdk:"conflicts"`
+}
+
+// PeerDNSConflictModel describes a DNS label shared by multiple peers.
+type PeerDNSConflictModel struct {
+	DnsLabel types.String `tfsdk:"dns_label"`
+	PeerIds  types.List   `tfsdk:"peer_ids"`
+}
+
+// TFType returns the Terraform object type for peer DNS conflicts.
+func (m PeerDNSConflictModel) TFType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"dns_label": types.StringType,
+			"peer_ids":  types.ListType{ElemType: types.StringType},
+		},
+	}
+}
+
+func (d *PeerDNSConflictsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_peer_dns_conflicts"
+}
+
+func (d *PeerDNSConflictsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Read Peers sharing the same DNS Label",
+		MarkdownDescription: "Read Peers sharing the same DNS Label, peers can end up with conflicting DNS labels after being renamed, see [NetBird Docs](https://docs.netbird.io/how-to/manage-dns-in-your-network) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"conflicts": schema.ListNestedAttribute{
+				MarkdownDescription: "DNS labels used by more than one peer, ordered by DNS label",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"dns_label": schema.StringAttribute{
+							MarkdownDescription: "Peer DNS Label",
+							Computed:            true,
+						},
+						"peer_ids": schema.ListAttribute{
+							MarkdownDescription: "IDs of the peers using the DNS label",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PeerDNSConflictsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// peerDNSConflicts groups peers by DNS label, ignoring case, and returns the labels used by more than one peer.
+func peerDNSConflicts(ctx context.Context, peers []api.Peer) (types.List, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	labels := map[string]string{}
+	byLabel := map[string][]string{}
+	for _, p := range peers {
+		if p.DnsLabel == "" {
+			continue
+		}
+		key := strings.ToLower(p.DnsLabel)
+		if _, ok := labels[key]; !ok {
+			labels[key] = p.DnsLabel
+		}
+		byLabel[key] = append(byLabel[key], p.Id)
+	}
+
+	keys := make([]string, 0, len(byLabel))
+	for k, ids := range byLabel {
+		if len(ids) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	conflicts := make([]PeerDNSConflictModel, len(keys))
+	for i, k := range keys {
+		ids, d := types.ListValueFrom(ctx, types.StringType, byLabel[k])
+		ret.Append(d...)
+		conflicts[i] = PeerDNSConflictModel{
+			DnsLabel: types.StringValue(labels[k]),
+			PeerIds:  ids,
+		}
+	}
+	if ret.HasError() {
+		return types.ListNull(PeerDNSConflictModel{}.TFType()), ret
+	}
+
+	l, d := types.ListValueFrom(ctx, PeerDNSConflictModel{}.TFType(), conflicts)
+	ret.Append(d...)
+	return l, ret
+}
+
+func (d *PeerDNSConflictsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PeerDNSConflictsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Conflicts can only be found by scanning all peers
+	peers, err := d.client.Peers.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Peers", formatAPIError(err))
+		return
+	}
+
+	conflicts, di := peerDNSConflicts(ctx, peers)
+	resp.Diagnostics.Append(di...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Conflicts = conflicts
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+func Test_PeerDNSConflictsDataSource_Read(t *testing.T) {
+	peers := []api.Peer{
+		{Id: "p1", DnsLabel: "server", Groups: []api.GroupMinimum{}},
+		{Id: "p2", DnsLabel: "laptop", Groups: []api.GroupMinimum{}},
+		{Id: "p3", DnsLabel: "Server", Groups: []api.GroupMinimum{}},
+		{Id: "p4", DnsLabel: "", Groups: []api.GroupMinimum{}},
+		{Id: "p5", DnsLabel: "", Groups: []api.GroupMinimum{}},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(peers)
+	})
+
+	d := &PeerDNSConflictsDataSource{client: client}
+	req, resp := testDataSourceRead(t, d, &PeerDNSConflictsModel{
+		Conflicts: types.ListNull(PeerDNSConflictModel{}.TFType()),
+	})
+	d.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var out PeerDNSConflictsModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	var conflicts []PeerDNSConflictModel
+	resp.Diagnostics.Append(out.Conflicts.ElementsAs(context.Background(), &conflicts, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	if len(conflicts) != 1 {
+		t.Fatalf("Expected 1 conflict, found %d", len(conflicts))
+	}
+	if conflicts[0].DnsLabel.ValueString() != "server" {
+		t.Fatalf("Expected conflict on dns_label server, found %s", conflicts[0].DnsLabel.ValueString())
+	}
+	var ids []string
+	resp.Diagnostics.Append(conflicts[0].PeerIds.ElementsAs(context.Background(), &ids, false)...)
+	if !slices.Equal(ids, []string{"p1", "p3"}) {
+		t.Fatalf("Expected conflicting peers [p1 p3], found %v", ids)
+	}
+}
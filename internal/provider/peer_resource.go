@@ -6,23 +6,31 @@ package provider
 import (
 	"context"
 	"fmt"
-	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
 	"github.com/netbirdio/netbird/shared/management/http/api"
 )
 
+// peerDefaultTimeout is used for peer operations without a configured timeout.
+const peerDefaultTimeout = 20 * time.Minute
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Peer{}
 var _ resource.ResourceWithImportState = &Peer{}
@@ -36,7 +44,7 @@ type Peer struct {
 	client *netbird.Client
 }
 
-// PeerModel describes the resource data model.
+// PeerModel describes the peer data model shared by the resource and data sources.
 type PeerModel struct {
 	Id                          types.String `tfsdk:"id"`
 	Name                        types.String `tfsdk:"name"`
@@ -49,6 +57,7 @@ type PeerModel struct {
 	GeonameId                   types.Int32  `tfsdk:"geoname_id"`
 	Version                     types.String `tfsdk:"version"`
 	Groups                      types.List   `tfsdk:"groups"`
+	GroupNames                  types.List   `tfsdk:"group_names"`
 	SshEnabled                  types.Bool   `tfsdk:"ssh_enabled"`
 	InactivityExpirationEnabled types.Bool   `tfsdk:"inactivity_expiration_enabled"`
 	ApprovalRequired            types.Bool   `tfsdk:"approval_required"`
@@ -63,6 +72,52 @@ type PeerModel struct {
 	CityName                    types.String `tfsdk:"city_name"`
 	SerialNumber                types.String `tfsdk:"serial_number"`
 	ExtraDnsLabels              types.List   `tfsdk:"extra_dns_labels"`
+	Ephemeral                   types.Bool   `tfsdk:"ephemeral"`
+}
+
+// TFType returns the Terraform object type for peers.
+func (m PeerModel) TFType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":                            types.StringType,
+			"name":                          types.StringType,
+			"ip":                            types.StringType,
+			"connection_ip":                 types.StringType,
+			"connected":                     types.BoolType,
+			"last_seen":                     types.StringType,
+			"os":                            types.StringType,
+			"kernel_version":                types.StringType,
+			"geoname_id":                    types.Int32Type,
+			"version":                       types.StringType,
+			"groups":                        types.ListType{ElemType: types.StringType},
+			"group_names":                   types.ListType{ElemType: types.StringType},
+			"ssh_enabled":                   types.BoolType,
+			"inactivity_expiration_enabled": types.BoolType,
+			"approval_required":             types.BoolType,
+			"dns_label":                     types.StringType,
+			"user_id":                       types.StringType,
+			"hostname":                      types.StringType,
+			"ui_version":                    types.StringType,
+			"login_expiration_enabled":      types.BoolType,
+			"login_expired":                 types.BoolType,
+			"last_login":                    types.StringType,
+			"country_code":                  types.StringType,
+			"city_name":                     types.StringType,
+			"serial_number":                 types.StringType,
+			"extra_dns_labels":              types.ListType{ElemType: types.StringType},
+			"ephemeral":                     types.BoolType,
+		},
+	}
+}
+
+// PeerResourceModel describes the resource data model.
+type PeerResourceModel struct {
+	PeerModel
+	DeletePeerOnDestroy types.Bool     `tfsdk:"delete_peer_on_destroy"`
+	Approved            types.Bool     `tfsdk:"approved"`
+	ExpectedGroups      types.Set      `tfsdk:"expected_groups"`
+	EnforceGroups       types.Bool     `tfsdk:"enforce_groups"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *Peer) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -81,7 +136,7 @@ func (r *Peer) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Peer Name",
+				MarkdownDescription: "Peer Name, set to an empty string to reset it to the peer hostname",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
@@ -99,7 +154,7 @@ func (r *Peer) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				Computed:            true,
 			},
 			"last_seen": schema.StringAttribute{
-				MarkdownDescription: "Peer Last Seen timedate",
+				MarkdownDescription: "Peer Last Seen timedate, null if the peer was never seen",
 				Computed:            true,
 			},
 			"os": schema.StringAttribute{
@@ -123,6 +178,11 @@ func (r *Peer) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"group_names": schema.ListAttribute{
+				MarkdownDescription: "Peer group names, in the same order as `groups`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 			"ssh_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Enable SSH to Peer",
 				Optional:            true,
@@ -139,7 +199,12 @@ func (r *Peer) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				MarkdownDescription: "Indicates whether peer needs approval",
 				Optional:            true,
 				Computed:            true,
-				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown(), peerApprovedPlanModifier{}},
+			},
+			"approved": schema.BoolAttribute{
+				MarkdownDescription: "Approve the peer if it is pending approval, approval_required reflects the approval state on the server",
+				Optional:            true,
+				Validators:          []validator.Bool{boolvalidator.ConflictsWith(path.MatchRoot("approval_required"))},
 			},
 			"dns_label": schema.StringAttribute{
 				MarkdownDescription: "Peer DNS Label",
@@ -168,7 +233,7 @@ func (r *Peer) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				Computed:            true,
 			},
 			"last_login": schema.StringAttribute{
-				MarkdownDescription: "Time of peer last login",
+				MarkdownDescription: "Time of peer last login, null if the peer never logged in",
 				Computed:            true,
 			},
 			"country_code": schema.StringAttribute{
@@ -183,11 +248,40 @@ func (r *Peer) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				MarkdownDescription: "Peer device serial number",
 				Computed:            true,
 			},
+			"ephemeral": schema.BoolAttribute{
+				MarkdownDescription: "Indicates whether the peer was registered with an ephemeral setup key and is removed automatically after being offline",
+				Computed:            true,
+			},
 			"extra_dns_labels": schema.ListAttribute{
 				MarkdownDescription: "Peer extra DNS Labels",
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"delete_peer_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Delete the peer from NetBird when the resource is destroyed, by default the peer is only removed from Terraform state",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"expected_groups": schema.SetAttribute{
+				MarkdownDescription: "Group IDs the peer is expected to be a member of, besides the All group, membership drift is reported as a warning, or as a change to this attribute with enforce_groups",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"enforce_groups": schema.BoolAttribute{
+				MarkdownDescription: "Correct membership drift from expected_groups on apply by adding the peer to missing groups and removing it from unexpected groups, refreshing only reports the drift",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -198,18 +292,18 @@ func (r *Peer) Configure(ctx context.Context, req resource.ConfigureRequest, res
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func peerAPIToTerraform(ctx context.Context, peer *api.Peer, data *PeerModel) diag.Diagnostics {
@@ -219,7 +313,7 @@ func peerAPIToTerraform(ctx context.Context, peer *api.Peer, data *PeerModel) di
 	data.Ip = types.StringValue(peer.Ip)
 	data.ConnectionIp = types.StringValue(peer.ConnectionIp)
 	data.Connected = types.BoolValue(peer.Connected)
-	data.LastSeen = types.StringValue(peer.LastSeen.Format(time.RFC3339))
+	data.LastSeen = timeStringOrNull(peer.LastSeen)
 	data.Os = types.StringValue(peer.Os)
 	data.KernelVersion = types.StringValue(peer.KernelVersion)
 	data.GeonameId = types.Int32Value(int32(peer.GeonameId))
@@ -233,25 +327,195 @@ func peerAPIToTerraform(ctx context.Context, peer *api.Peer, data *PeerModel) di
 	data.UiVersion = types.StringValue(peer.UiVersion)
 	data.LoginExpirationEnabled = types.BoolValue(peer.LoginExpirationEnabled)
 	data.LoginExpired = types.BoolValue(peer.LoginExpired)
-	data.LastLogin = types.StringValue(peer.LastLogin.Format(time.RFC3339))
+	data.LastLogin = timeStringOrNull(peer.LastLogin)
 	data.CountryCode = types.StringValue(peer.CountryCode)
 	data.CityName = types.StringValue(peer.CityName)
 	data.SerialNumber = types.StringValue(peer.SerialNumber)
+	data.Ephemeral = types.BoolValue(peer.Ephemeral)
 	groupIDs := make([]string, len(peer.Groups))
+	groupNames := make([]string, len(peer.Groups))
 	for i, g := range peer.Groups {
 		groupIDs[i] = g.Id
+		groupNames[i] = g.Name
 	}
 	l, diag := types.ListValueFrom(ctx, types.StringType, groupIDs)
 	ret.Append(diag...)
 	data.Groups = l
+	l, diag = types.ListValueFrom(ctx, types.StringType, groupNames)
+	ret.Append(diag...)
+	data.GroupNames = l
 	l, diag = types.ListValueFrom(ctx, types.StringType, peer.ExtraDnsLabels)
 	ret.Append(diag...)
 	data.ExtraDnsLabels = l
 	return ret
 }
 
+var _ planmodifier.Bool = peerApprovedPlanModifier{}
+
+// peerApprovedPlanModifier plans approval_required as false when the peer is approved through the approved attribute.
+type peerApprovedPlanModifier struct{}
+
+func (m peerApprovedPlanModifier) Description(ctx context.Context) string {
+	return "approval_required is planned as false when approved is true"
+}
+
+func (m peerApprovedPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m peerApprovedPlanModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var approved types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("approved"), &approved)...)
+	if approved.ValueBool() {
+		resp.PlanValue = types.BoolValue(false)
+	}
+}
+
+// peerKeepResetName keeps the explicitly empty name configured in name while the peer name matches its hostname,
+// as an empty name resets the peer name to its hostname.
+func peerKeepResetName(name types.String, data *PeerModel) {
+	if !name.IsNull() && !name.IsUnknown() && name.ValueString() == "" && data.Name.ValueString() == data.Hostname.ValueString() {
+		data.Name = types.StringValue("")
+	}
+}
+
+// peerName returns the peer name to send to the API, an empty name is replaced with the peer hostname.
+func peerName(name types.String, hostname string) string {
+	if name.ValueString() == "" {
+		return hostname
+	}
+	return name.ValueString()
+}
+
+// peerGroupAllID returns the ID of the All group every peer belongs to, resolved by name like the Management API does.
+func peerGroupAllID(ctx context.Context, client *netbird.Client) (string, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	group, err := client.Groups.GetByName(ctx, "All")
+	if err != nil {
+		if !isNotFound(err) {
+			ret.AddError("Error getting Group", formatAPIError(err))
+		}
+		return "", ret
+	}
+	return group.Id, ret
+}
+
+// peerGroupDiff returns the expected_groups the peer is missing from and the groups it unexpectedly belongs to, ignoring the All group.
+func peerGroupDiff(ctx context.Context, client *netbird.Client, peer *api.Peer, data *PeerResourceModel) ([]string, []string, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	var expected []string
+	ret.Append(data.ExpectedGroups.ElementsAs(ctx, &expected, false)...)
+	if ret.HasError() {
+		return nil, nil, ret
+	}
+	allID, d := peerGroupAllID(ctx, client)
+	ret.Append(d...)
+	if ret.HasError() {
+		return nil, nil, ret
+	}
+
+	var missing, unexpected []string
+	actual := make(map[string]bool, len(peer.Groups))
+	for _, g := range peer.Groups {
+		actual[g.Id] = true
+		if g.Id != allID && !slices.Contains(expected, g.Id) {
+			unexpected = append(unexpected, g.Id)
+		}
+	}
+	for _, id := range expected {
+		if !actual[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing, unexpected, ret
+}
+
+// peerGroupDrift reports membership drift from expected_groups without changing any group. With enforce_groups the
+// actual groups are stored in expected_groups so the plan shows the correction applied by peerEnforceGroups, otherwise
+// drift is reported as a warning.
+func peerGroupDrift(ctx context.Context, client *netbird.Client, peer *api.Peer, data *PeerResourceModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	if data.ExpectedGroups.IsNull() || data.ExpectedGroups.IsUnknown() {
+		return ret
+	}
+
+	missing, unexpected, d := peerGroupDiff(ctx, client, peer, data)
+	ret.Append(d...)
+	if ret.HasError() || (len(missing) == 0 && len(unexpected) == 0) {
+		return ret
+	}
+
+	if !data.EnforceGroups.ValueBool() {
+		ret.AddAttributeWarning(path.Root("expected_groups"), "Peer Group Drift", fmt.Sprintf("Peer %s is missing from groups [%s] and unexpectedly in groups [%s]", peer.Id, strings.Join(missing, ", "), strings.Join(unexpected, ", ")))
+		return ret
+	}
+
+	var expected []string
+	ret.Append(data.ExpectedGroups.ElementsAs(ctx, &expected, false)...)
+	actual := slices.DeleteFunc(append(slices.Clone(expected), unexpected...), func(id string) bool { return slices.Contains(missing, id) })
+	data.ExpectedGroups, d = types.SetValueFrom(ctx, types.StringType, actual)
+	ret.Append(d...)
+	return ret
+}
+
+// peerEnforceGroups corrects membership drift from expected_groups with enforce_groups by updating the affected groups,
+// in which case the refreshed peer is returned, otherwise drift is reported as a warning.
+func peerEnforceGroups(ctx context.Context, client *netbird.Client, peer *api.Peer, data *PeerResourceModel) (*api.Peer, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	if !data.EnforceGroups.ValueBool() {
+		return peer, peerGroupDrift(ctx, client, peer, data)
+	}
+	if data.ExpectedGroups.IsNull() || data.ExpectedGroups.IsUnknown() {
+		return peer, ret
+	}
+
+	missing, unexpected, d := peerGroupDiff(ctx, client, peer, data)
+	ret.Append(d...)
+	if ret.HasError() || (len(missing) == 0 && len(unexpected) == 0) {
+		return peer, ret
+	}
+
+	for _, id := range append(missing, unexpected...) {
+		group, err := client.Groups.Get(ctx, id)
+		if err != nil {
+			ret.AddError("Error getting Group", formatAPIError(err))
+			return peer, ret
+		}
+
+		peers := make([]string, 0, len(group.Peers)+1)
+		for _, p := range group.Peers {
+			if p.Id != peer.Id {
+				peers = append(peers, p.Id)
+			}
+		}
+		if slices.Contains(missing, id) {
+			peers = append(peers, peer.Id)
+		}
+
+		_, err = client.Groups.Update(ctx, id, api.GroupRequest{
+			Name:      group.Name,
+			Peers:     &peers,
+			Resources: &group.Resources,
+		})
+		if err != nil {
+			ret.AddError("Error updating Group", formatAPIError(err))
+			return peer, ret
+		}
+	}
+
+	peer, err := client.Peers.Get(ctx, peer.Id)
+	if err != nil {
+		ret.AddError("Error getting Peer", formatAPIError(err))
+	}
+	return peer, ret
+}
+
 func (r *Peer) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data PeerModel
+	var data PeerResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -260,9 +524,17 @@ func (r *Peer) Create(ctx context.Context, req resource.CreateRequest, resp *res
 		return
 	}
 
+	createTimeout, d := data.Timeouts.Create(ctx, peerDefaultTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	peer, err := r.client.Peers.Get(ctx, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error getting Peer", err.Error())
+		resp.Diagnostics.AddError("Error getting Peer", formatAPIError(err))
 		return
 	}
 
@@ -286,8 +558,8 @@ func (r *Peer) Create(ctx context.Context, req resource.CreateRequest, resp *res
 		updateRequest.LoginExpirationEnabled = data.LoginExpirationEnabled.ValueBool()
 		updateRequired = true
 	}
-	if !data.Name.IsUnknown() && data.Name.ValueString() != peer.Name {
-		updateRequest.Name = data.Name.ValueString()
+	if name := peerName(data.Name, peer.Hostname); !data.Name.IsUnknown() && name != peer.Name {
+		updateRequest.Name = name
 		updateRequired = true
 	}
 	if !data.SshEnabled.IsUnknown() && data.SshEnabled.ValueBool() != peer.SshEnabled {
@@ -298,12 +570,20 @@ func (r *Peer) Create(ctx context.Context, req resource.CreateRequest, resp *res
 	if updateRequired {
 		peer, err = r.client.Peers.Update(ctx, peer.Id, updateRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating Peer", err.Error())
+			resp.Diagnostics.AddError("Error updating Peer", formatAPIError(err))
 			return
 		}
 	}
 
-	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data)...)
+	peer, d = peerEnforceGroups(ctx, r.client, peer, &data)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name
+	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data.PeerModel)...)
+	peerKeepResetName(name, &data.PeerModel)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -314,7 +594,7 @@ func (r *Peer) Create(ctx context.Context, req resource.CreateRequest, resp *res
 }
 
 func (r *Peer) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data PeerModel
+	var data PeerResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -323,6 +603,14 @@ func (r *Peer) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 		return
 	}
 
+	readTimeout, d := data.Timeouts.Read(ctx, peerDefaultTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	peer, err := r.client.Peers.Get(ctx, data.Id.ValueString())
 
 	if err != nil {
@@ -330,12 +618,19 @@ func (r *Peer) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting Peer", err.Error())
+			resp.Diagnostics.AddError("Error getting Peer", formatAPIError(err))
 		}
 		return
 	}
 
-	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data)...)
+	resp.Diagnostics.Append(peerGroupDrift(ctx, r.client, peer, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name
+	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data.PeerModel)...)
+	peerKeepResetName(name, &data.PeerModel)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -346,7 +641,7 @@ func (r *Peer) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 }
 
 func (r *Peer) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data PeerModel
+	var data PeerResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -355,25 +650,47 @@ func (r *Peer) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 		return
 	}
 
+	updateTimeout, d := data.Timeouts.Update(ctx, peerDefaultTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	if data.Id.ValueString() == "" {
 		r.Create(ctx, resource.CreateRequest{Config: req.Config, Plan: req.Plan, ProviderMeta: req.Config}, (*resource.CreateResponse)(resp))
 		return
 	}
 
+	var hostname types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("hostname"), &hostname)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	peer, err := r.client.Peers.Update(ctx, data.Id.ValueString(), api.PeerRequest{
 		ApprovalRequired:            data.ApprovalRequired.ValueBoolPointer(),
 		InactivityExpirationEnabled: data.InactivityExpirationEnabled.ValueBool(),
 		LoginExpirationEnabled:      data.LoginExpirationEnabled.ValueBool(),
-		Name:                        data.Name.ValueString(),
+		Name:                        peerName(data.Name, hostname.ValueString()),
 		SshEnabled:                  data.SshEnabled.ValueBool(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Peer", err.Error())
+		resp.Diagnostics.AddError("Error updating Peer", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data)...)
+	peer, d = peerEnforceGroups(ctx, r.client, peer, &data)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name
+	resp.Diagnostics.Append(peerAPIToTerraform(ctx, peer, &data.PeerModel)...)
+	peerKeepResetName(name, &data.PeerModel)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -391,7 +708,7 @@ func (r *Peer) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 }
 
 func (r *Peer) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data PeerModel
+	var data PeerResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -400,15 +717,64 @@ func (r *Peer) Delete(ctx context.Context, req resource.DeleteRequest, resp *res
 		return
 	}
 
-	// Do not delete actual peers in acceptance tests to make running locally easier
-	if _, ok := os.LookupEnv("TF_ACC"); !ok {
-		err := r.client.Peers.Delete(ctx, data.Id.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Error deleting Peer", err.Error())
+	deleteTimeout, d := data.Timeouts.Delete(ctx, peerDefaultTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Peers are registered by the NetBird agent, only remove them from state
+	// unless deletion is explicitly requested
+	if !data.DeletePeerOnDestroy.ValueBool() {
+		return
+	}
+
+	err := r.client.Peers.Delete(ctx, data.Id.ValueString())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Peer", formatAPIError(err))
+	}
+}
+
+// peerBySerialNumber returns the ID of the only peer with the given device serial number.
+func peerBySerialNumber(ctx context.Context, client *netbird.Client, serialNumber string) (string, error) {
+	if serialNumber == "" {
+		return "", fmt.Errorf("serial number must not be empty, peers without a serial number can only be imported by ID")
+	}
+
+	peers, err := client.Peers.List(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, p := range peers {
+		if p.SerialNumber == serialNumber {
+			ids = append(ids, p.Id)
 		}
 	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no peer with serial number %q found", serialNumber)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("multiple peers have serial number %q (%s)", serialNumber, strings.Join(ids, ", "))
+	}
 }
 
 func (r *Peer) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := req.ID
+	if serialNumber, ok := strings.CutPrefix(req.ID, "serial="); ok {
+		var err error
+		id, err = peerBySerialNumber(ctx, r.client, serialNumber)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing Peer", formatAPIError(err))
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete_peer_on_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("enforce_groups"), false)...)
 }
@@ -2,13 +2,27 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -54,6 +68,7 @@ func Test_peerAPIToTerraform(t *testing.T) {
 				GeonameId:                   types.Int32Value(1234),
 				Version:                     types.StringValue("0.41.0"),
 				Groups:                      types.ListValueMust(types.StringType, []attr.Value{}),
+				GroupNames:                  types.ListValueMust(types.StringType, []attr.Value{}),
 				SshEnabled:                  types.BoolValue(false),
 				InactivityExpirationEnabled: types.BoolValue(false),
 				ApprovalRequired:            types.BoolValue(false),
@@ -68,6 +83,7 @@ func Test_peerAPIToTerraform(t *testing.T) {
 				CityName:                    types.StringValue("Cairo"),
 				SerialNumber:                types.StringValue("1234"),
 				ExtraDnsLabels:              types.ListValueMust(types.StringType, []attr.Value{}),
+				Ephemeral:                   types.BoolValue(false),
 			},
 		},
 		{
@@ -78,7 +94,7 @@ func Test_peerAPIToTerraform(t *testing.T) {
 				ExtraDnsLabels:              []string{"test.example.local"},
 				GeonameId:                   1234,
 				Hostname:                    "ip-1-2-3-5",
-				Groups:                      []api.GroupMinimum{{Id: "g1"}, {Id: "g2"}},
+				Groups:                      []api.GroupMinimum{{Id: "g1", Name: "devs"}, {Id: "g2", Name: "ops"}},
 				Id:                          "p2",
 				Ip:                          "100.1.2.4",
 				KernelVersion:               "6.8.0",
@@ -97,6 +113,7 @@ func Test_peerAPIToTerraform(t *testing.T) {
 				LoginExpired:                true,
 				SshEnabled:                  true,
 				UiVersion:                   "0.41.0",
+				Ephemeral:                   true,
 			},
 			expected: PeerModel{
 				Id:                          types.StringValue("p2"),
@@ -110,6 +127,7 @@ func Test_peerAPIToTerraform(t *testing.T) {
 				GeonameId:                   types.Int32Value(1234),
 				Version:                     types.StringValue("0.41.0"),
 				Groups:                      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1"), types.StringValue("g2")}),
+				GroupNames:                  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("devs"), types.StringValue("ops")}),
 				SshEnabled:                  types.BoolValue(true),
 				InactivityExpirationEnabled: types.BoolValue(true),
 				ApprovalRequired:            types.BoolValue(true),
@@ -124,6 +142,45 @@ func Test_peerAPIToTerraform(t *testing.T) {
 				CityName:                    types.StringValue("Berlin"),
 				SerialNumber:                types.StringValue("1234"),
 				ExtraDnsLabels:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("test.example.local")}),
+				Ephemeral:                   types.BoolValue(true),
+			},
+		},
+		{
+			resource: &api.Peer{
+				Id:             "p3",
+				Name:           "never-logged-in",
+				Hostname:       "never-logged-in",
+				Groups:         []api.GroupMinimum{},
+				ExtraDnsLabels: []string{},
+			},
+			expected: PeerModel{
+				Id:                          types.StringValue("p3"),
+				Name:                        types.StringValue("never-logged-in"),
+				Ip:                          types.StringValue(""),
+				ConnectionIp:                types.StringValue(""),
+				Connected:                   types.BoolValue(false),
+				LastSeen:                    types.StringNull(),
+				Os:                          types.StringValue(""),
+				KernelVersion:               types.StringValue(""),
+				GeonameId:                   types.Int32Value(0),
+				Version:                     types.StringValue(""),
+				Groups:                      types.ListValueMust(types.StringType, []attr.Value{}),
+				GroupNames:                  types.ListValueMust(types.StringType, []attr.Value{}),
+				SshEnabled:                  types.BoolValue(false),
+				InactivityExpirationEnabled: types.BoolValue(false),
+				ApprovalRequired:            types.BoolValue(false),
+				DnsLabel:                    types.StringValue(""),
+				UserId:                      types.StringValue(""),
+				Hostname:                    types.StringValue("never-logged-in"),
+				UiVersion:                   types.StringValue(""),
+				LoginExpirationEnabled:      types.BoolValue(false),
+				LoginExpired:                types.BoolValue(false),
+				LastLogin:                   types.StringNull(),
+				CountryCode:                 types.StringValue(""),
+				CityName:                    types.StringValue(""),
+				SerialNumber:                types.StringValue(""),
+				ExtraDnsLabels:              types.ListValueMust(types.StringType, []attr.Value{}),
+				Ephemeral:                   types.BoolValue(false),
 			},
 		},
 	}
@@ -141,6 +198,437 @@ func Test_peerAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_peerDelete(t *testing.T) {
+	cases := []struct {
+		deletePeerOnDestroy bool
+		expectedDeletes     int
+	}{
+		{deletePeerOnDestroy: false, expectedDeletes: 0},
+		{deletePeerOnDestroy: true, expectedDeletes: 1},
+	}
+
+	for _, c := range cases {
+		deletes := 0
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete && r.URL.Path == "/api/peers/p1" {
+				deletes++
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("{}"))
+		})
+
+		r := &Peer{client: client}
+		data := PeerResourceModel{
+			PeerModel: PeerModel{
+				Id:             types.StringValue("p1"),
+				Groups:         types.ListNull(types.StringType),
+				GroupNames:     types.ListNull(types.StringType),
+				ExtraDnsLabels: types.ListNull(types.StringType),
+			},
+			DeletePeerOnDestroy: types.BoolValue(c.deletePeerOnDestroy),
+			ExpectedGroups:      types.SetNull(types.StringType),
+			Timeouts:            testPeerTimeouts(types.StringNull()),
+		}
+		resp := tfresource.DeleteResponse{}
+		r.Delete(context.Background(), tfresource.DeleteRequest{State: testResourceState(t, r, &data)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if deletes != c.expectedDeletes {
+			t.Fatalf("Expected %d delete calls with delete_peer_on_destroy=%t, found %d", c.expectedDeletes, c.deletePeerOnDestroy, deletes)
+		}
+	}
+}
+
+// testPeerTimeouts builds a peer timeouts block with the given create timeout.
+func testPeerTimeouts(create types.String) timeouts.Value {
+	return timeouts.Value{Object: types.ObjectValueMust(
+		map[string]attr.Type{"create": types.StringType, "read": types.StringType, "update": types.StringType, "delete": types.StringType},
+		map[string]attr.Value{"create": create, "read": types.StringNull(), "update": types.StringNull(), "delete": types.StringNull()},
+	)}
+}
+
+func Test_Peer_Create_timeout(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "p1", "name": "peer1", "groups": []}`))
+	})
+
+	r := &Peer{client: client}
+	plan := testResourceState(t, r, &PeerResourceModel{
+		PeerModel: PeerModel{
+			Id:             types.StringValue("p1"),
+			Groups:         types.ListNull(types.StringType),
+			GroupNames:     types.ListNull(types.StringType),
+			ExtraDnsLabels: types.ListNull(types.StringType),
+		},
+		DeletePeerOnDestroy: types.BoolValue(false),
+		ExpectedGroups:      types.SetNull(types.StringType),
+		Timeouts:            testPeerTimeouts(types.StringValue("1s")),
+	})
+
+	start := time.Now()
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "context deadline exceeded") {
+		t.Fatalf("Expected create to fail with a timeout, found %v", resp.Diagnostics.Errors())
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("Expected create to time out after 1s, took %s", elapsed)
+	}
+}
+
+func Test_Peer_groupDrift(t *testing.T) {
+	cases := []struct {
+		update                 bool
+		enforce                bool
+		expectedWarnings       int
+		expectedUpdates        map[string]string
+		expectedGroups         []string
+		expectedExpectedGroups []string
+	}{
+		// Refreshing only reports drift, with enforce_groups through expected_groups
+		{enforce: false, expectedWarnings: 1, expectedUpdates: map[string]string{}, expectedGroups: []string{"all", "g1", "g3"}, expectedExpectedGroups: []string{"g1", "g2"}},
+		{enforce: true, expectedUpdates: map[string]string{}, expectedGroups: []string{"all", "g1", "g3"}, expectedExpectedGroups: []string{"g1", "g3"}},
+		// Applying corrects drift with enforce_groups
+		{update: true, enforce: false, expectedWarnings: 1, expectedUpdates: map[string]string{}, expectedGroups: []string{"all", "g1", "g3"}, expectedExpectedGroups: []string{"g1", "g2"}},
+		{update: true, enforce: true, expectedUpdates: map[string]string{"g2": `["p2","p1"]`, "g3": `["p2"]`}, expectedGroups: []string{"all", "g1", "g2"}, expectedExpectedGroups: []string{"g1", "g2"}},
+	}
+
+	for _, c := range cases {
+		peerGroups := []string{`{"id":"all","name":"All"}`, `{"id":"g1","name":"G1"}`, `{"id":"g3","name":"G3"}`}
+		updates := map[string]string{}
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/api/peers/p1":
+				_, _ = w.Write([]byte(`{"id":"p1","name":"peer1","groups":[` + strings.Join(peerGroups, ",") + `]}`))
+			case r.URL.Path == "/api/groups" && r.URL.Query().Get("name") == "All":
+				_, _ = w.Write([]byte(`[{"id":"all","name":"All","peers":[],"resources":[]}]`))
+			case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/groups/"):
+				id := strings.TrimPrefix(r.URL.Path, "/api/groups/")
+				peers := `[{"id":"p2","name":"peer2"}]`
+				if id == "g3" {
+					peers = `[{"id":"p1","name":"peer1"},{"id":"p2","name":"peer2"}]`
+				}
+				_, _ = w.Write([]byte(`{"id":"` + id + `","name":"` + id + `","peers":` + peers + `,"resources":[]}`))
+			case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/groups/"):
+				var req api.GroupRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				peers, _ := json.Marshal(req.Peers)
+				id := strings.TrimPrefix(r.URL.Path, "/api/groups/")
+				updates[id] = string(peers)
+				if id == "g2" {
+					peerGroups = append(peerGroups, `{"id":"g2","name":"G2"}`)
+				} else {
+					peerGroups = slices.DeleteFunc(peerGroups, func(g string) bool { return strings.Contains(g, `"`+id+`"`) })
+				}
+				_, _ = w.Write([]byte(`{"id":"` + id + `","name":"` + id + `","peers":[],"resources":[]}`))
+			}
+		})
+
+		r := &Peer{client: client}
+		state := testResourceState(t, r, &PeerResourceModel{
+			PeerModel: PeerModel{
+				Id:             types.StringValue("p1"),
+				Name:           types.StringValue("peer1"),
+				Groups:         types.ListNull(types.StringType),
+				GroupNames:     types.ListNull(types.StringType),
+				ExtraDnsLabels: types.ListNull(types.StringType),
+			},
+			DeletePeerOnDestroy: types.BoolValue(false),
+			ExpectedGroups:      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("g1"), types.StringValue("g2")}),
+			EnforceGroups:       types.BoolValue(c.enforce),
+			Timeouts:            testPeerTimeouts(types.StringNull()),
+		})
+		var diags diag.Diagnostics
+		var out tfsdk.State
+		if c.update {
+			resp := tfresource.UpdateResponse{State: state}
+			r.Update(context.Background(), tfresource.UpdateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}, State: state}, &resp)
+			diags, out = resp.Diagnostics, resp.State
+		} else {
+			resp := tfresource.ReadResponse{State: state}
+			r.Read(context.Background(), tfresource.ReadRequest{State: state}, &resp)
+			diags, out = resp.Diagnostics, resp.State
+		}
+		if diags.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+		}
+		if diags.WarningsCount() != c.expectedWarnings {
+			t.Fatalf("Expected %d warnings with enforce_groups=%t, found %v", c.expectedWarnings, c.enforce, diags.Warnings())
+		}
+		if !reflect.DeepEqual(updates, c.expectedUpdates) {
+			t.Fatalf("Expected group updates %v with update=%t enforce_groups=%t, found %v", c.expectedUpdates, c.update, c.enforce, updates)
+		}
+
+		var model PeerResourceModel
+		diags.Append(out.Get(context.Background(), &model)...)
+		var groups, expectedGroups []string
+		diags.Append(model.Groups.ElementsAs(context.Background(), &groups, false)...)
+		diags.Append(model.ExpectedGroups.ElementsAs(context.Background(), &expectedGroups, false)...)
+		if diags.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", diags.Errors())
+		}
+		slices.Sort(expectedGroups)
+		if !reflect.DeepEqual(groups, c.expectedGroups) {
+			t.Fatalf("Expected groups %v, found %v", c.expectedGroups, groups)
+		}
+		if !reflect.DeepEqual(expectedGroups, c.expectedExpectedGroups) {
+			t.Fatalf("Expected expected_groups %v in state with update=%t enforce_groups=%t, found %v", c.expectedExpectedGroups, c.update, c.enforce, expectedGroups)
+		}
+	}
+}
+
+func Test_Peer_Create_approved(t *testing.T) {
+	var updated api.PeerRequest
+	updates := 0
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			updates++
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_, _ = w.Write([]byte(`{"id":"p1","name":"peer1","approval_required":false,"groups":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"p1","name":"peer1","approval_required":true,"groups":[]}`))
+	})
+
+	r := &Peer{client: client}
+	data := PeerResourceModel{
+		PeerModel: PeerModel{
+			Id:               types.StringValue("p1"),
+			ApprovalRequired: types.BoolNull(),
+			Groups:           types.ListNull(types.StringType),
+			GroupNames:       types.ListNull(types.StringType),
+			ExtraDnsLabels:   types.ListNull(types.StringType),
+		},
+		DeletePeerOnDestroy: types.BoolValue(false),
+		Approved:            types.BoolValue(true),
+		ExpectedGroups:      types.SetNull(types.StringType),
+		Timeouts:            testPeerTimeouts(types.StringNull()),
+	}
+	config := testResourceState(t, r, &data)
+
+	// approved plans approval_required as false, which approves the pending peer on apply
+	modifyResp := planmodifier.BoolResponse{PlanValue: types.BoolNull()}
+	peerApprovedPlanModifier{}.PlanModifyBool(context.Background(), planmodifier.BoolRequest{
+		Path:        path.Root("approval_required"),
+		Config:      tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+		ConfigValue: types.BoolNull(),
+		PlanValue:   types.BoolNull(),
+	}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", modifyResp.Diagnostics.Errors())
+	}
+	if !modifyResp.PlanValue.Equal(types.BoolValue(false)) {
+		t.Fatalf("Expected approval_required to be planned as false, found %s", modifyResp.PlanValue)
+	}
+
+	data.ApprovalRequired = modifyResp.PlanValue
+	plan := testResourceState(t, r, &data)
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+	if updates != 1 || updated.ApprovalRequired == nil || *updated.ApprovalRequired {
+		t.Fatalf("Expected a single update approving the peer, found %d updates with approval_required %v", updates, updated.ApprovalRequired)
+	}
+
+	var out PeerResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	if out.ApprovalRequired.ValueBool() {
+		t.Fatalf("Expected approval_required to be false after approval")
+	}
+}
+
+func Test_PeerDataSource_Read_hostname(t *testing.T) {
+	peers := `[
+		{"id": "p1", "name": "peer1", "hostname": "web.example.com", "groups": []},
+		{"id": "p2", "name": "peer2", "hostname": "web", "groups": []},
+		{"id": "p3", "name": "peer3", "hostname": "db.example.com", "groups": []},
+		{"id": "p4", "name": "peer4", "hostname": "db.example.org", "groups": []}
+	]`
+	cases := []struct {
+		name           string
+		hostname       types.String
+		hostnamePrefix types.String
+		peerName       types.String
+		expectedId     string
+		expectedErr    string
+	}{
+		{name: "exact hostname", hostname: types.StringValue("web.example.com"), hostnamePrefix: types.StringNull(), peerName: types.StringNull(), expectedId: "p1"},
+		{name: "exact hostname mismatch", hostname: types.StringValue("db"), hostnamePrefix: types.StringNull(), peerName: types.StringNull(), expectedErr: "Not Found"},
+		{name: "prefix", hostname: types.StringNull(), hostnamePrefix: types.StringValue("db.example.c"), peerName: types.StringNull(), expectedId: "p3"},
+		{name: "prefix prefers exact hostname", hostname: types.StringNull(), hostnamePrefix: types.StringValue("web"), peerName: types.StringNull(), expectedId: "p2"},
+		{name: "prefix with name", hostname: types.StringNull(), hostnamePrefix: types.StringValue("db."), peerName: types.StringValue("peer4"), expectedId: "p4"},
+		{name: "ambiguous prefix", hostname: types.StringNull(), hostnamePrefix: types.StringValue("db."), peerName: types.StringNull(), expectedErr: "Multiple Matches"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(peers))
+			})
+
+			d := &PeerDataSource{client: client}
+			req, resp := testDataSourceRead(t, d, &PeerDataSourceModel{
+				PeerModel: PeerModel{
+					Name:           c.peerName,
+					Hostname:       c.hostname,
+					Groups:         types.ListNull(types.StringType),
+					GroupNames:     types.ListNull(types.StringType),
+					ExtraDnsLabels: types.ListNull(types.StringType),
+				},
+				HostnamePrefix: c.hostnamePrefix,
+			})
+			d.Read(context.Background(), req, resp)
+			if c.expectedErr != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedErr {
+					t.Fatalf("Expected %s error, found %v", c.expectedErr, resp.Diagnostics.Errors())
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+
+			var out PeerDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+			if out.Id.ValueString() != c.expectedId {
+				t.Fatalf("Expected peer %s, found %s", c.expectedId, out.Id.ValueString())
+			}
+		})
+	}
+}
+
+func Test_PeerDataSource_Read_serialNumber(t *testing.T) {
+	cases := []struct {
+		serialNumber  string
+		expectedId    string
+		expectedError string
+	}{
+		{serialNumber: "SN-1", expectedId: "p1"},
+		{serialNumber: "SN-2", expectedError: "Multiple Matches"},
+		{serialNumber: "SN-3", expectedError: "Not Found"},
+	}
+
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "p1", "name": "peer1", "serial_number": "SN-1", "groups": []},
+			{"id": "p2", "name": "peer2", "serial_number": "SN-2", "groups": []},
+			{"id": "p3", "name": "peer3", "serial_number": "SN-2", "groups": []}
+		]`))
+	})
+
+	for _, c := range cases {
+		d := &PeerDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &PeerDataSourceModel{
+			PeerModel: PeerModel{
+				SerialNumber:   types.StringValue(c.serialNumber),
+				Groups:         types.ListNull(types.StringType),
+				GroupNames:     types.ListNull(types.StringType),
+				ExtraDnsLabels: types.ListNull(types.StringType),
+			},
+		})
+		d.Read(context.Background(), req, resp)
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() == 0 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error for %s, found %v", c.expectedError, c.serialNumber, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out PeerDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		if out.Id.ValueString() != c.expectedId {
+			t.Fatalf("Expected peer %s for %s, found %s", c.expectedId, c.serialNumber, out.Id.ValueString())
+		}
+	}
+}
+
+func Test_PeerDataSource_serialNumberValidation(t *testing.T) {
+	cases := []struct {
+		serialNumber types.String
+		valid        bool
+	}{
+		{serialNumber: types.StringValue("SN-1"), valid: true},
+		{serialNumber: types.StringNull(), valid: true},
+		{serialNumber: types.StringValue(""), valid: false},
+	}
+
+	d := &PeerDataSource{}
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		resp := validator.StringResponse{}
+		for _, v := range schemaResp.Schema.Attributes["serial_number"].(dsschema.StringAttribute).Validators {
+			v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("serial_number"), ConfigValue: c.serialNumber}, &resp)
+		}
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Fatalf("Expected valid=%t for %s, found %v", c.valid, c.serialNumber, resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func Test_Peer_ImportState(t *testing.T) {
+	cases := []struct {
+		id            string
+		expectedId    string
+		expectedError bool
+	}{
+		{id: "p1", expectedId: "p1"},
+		{id: "serial=SN-1", expectedId: "p1"},
+		{id: "serial=SN-2", expectedError: true},
+		{id: "serial=SN-3", expectedError: true},
+		{id: "serial=", expectedError: true},
+	}
+
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "p1", "name": "peer1", "serial_number": "SN-1", "groups": []},
+			{"id": "p2", "name": "peer2", "serial_number": "SN-2", "groups": []},
+			{"id": "p3", "name": "peer3", "serial_number": "SN-2", "groups": []},
+			{"id": "p4", "name": "peer4", "serial_number": "", "groups": []}
+		]`))
+	})
+
+	r := &Peer{client: client}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		resp := tfresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}}
+		r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: c.id}, &resp)
+		if c.expectedError {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Error importing Peer" {
+				t.Fatalf("Expected import of %q to fail, found %v", c.id, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var id types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+		if id.ValueString() != c.expectedId {
+			t.Fatalf("Expected import of %q to set id %s, found %s", c.id, c.expectedId, id)
+		}
+	}
+}
+
 func Test_Peer_Create(t *testing.T) {
 	rName := "p" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_peer." + rName
@@ -210,6 +698,84 @@ func Test_Peer_Update(t *testing.T) {
 	})
 }
 
+func Test_peerName(t *testing.T) {
+	cases := []struct {
+		name     types.String
+		expected string
+	}{
+		{name: types.StringValue("custom"), expected: "custom"},
+		{name: types.StringValue(""), expected: "host1"},
+		{name: types.StringNull(), expected: "host1"},
+	}
+
+	for _, c := range cases {
+		if out := peerName(c.name, "host1"); out != c.expected {
+			t.Fatalf("Expected peer name %s, found %s", c.expected, out)
+		}
+	}
+}
+
+func Test_peerKeepResetName(t *testing.T) {
+	cases := []struct {
+		name     types.String
+		peerName string
+		expected string
+	}{
+		{name: types.StringValue(""), peerName: "host1", expected: ""},
+		{name: types.StringValue(""), peerName: "renamed", expected: "renamed"},
+		{name: types.StringValue("custom"), peerName: "host1", expected: "host1"},
+		{name: types.StringNull(), peerName: "host1", expected: "host1"},
+		{name: types.StringUnknown(), peerName: "host1", expected: "host1"},
+	}
+
+	for _, c := range cases {
+		data := PeerModel{Name: c.name}
+		outDiag := peerAPIToTerraform(context.Background(), &api.Peer{Id: "p1", Name: c.peerName, Hostname: "host1"}, &data)
+		if outDiag.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+		}
+		peerKeepResetName(c.name, &data)
+		if data.Name.ValueString() != c.expected {
+			t.Fatalf("Expected peer name %q for %s, found %q", c.expected, c.name, data.Name.ValueString())
+		}
+	}
+}
+
+func Test_Peer_ResetName(t *testing.T) {
+	rName := "p" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_peer." + rName
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: rName,
+				Config:       testPeerResource(rName, `peer2`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "name", rName),
+				),
+			},
+			{
+				ResourceName: rName,
+				Config:       testPeerResource(rName, `peer2`, ``),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "name", ""),
+					func(s *terraform.State) error {
+						peer, err := testClient().Peers.Get(context.Background(), "peer2")
+						if err != nil {
+							return err
+						}
+						if peer.Name != peer.Hostname {
+							return fmt.Errorf("Peer name mismatch, expected hostname %s, found %s on management server", peer.Hostname, peer.Name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testPeerResource(rName, id, name string) string {
 	return fmt.Sprintf(`resource "netbird_peer" "%s" {
 	id = "%s"
@@ -6,10 +6,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
 	"github.com/netbirdio/netbird/shared/management/http/api"
@@ -35,6 +40,7 @@ type PeersModel struct {
 	GeonameId                   types.Int32  `tfsdk:"geoname_id"`
 	Version                     types.String `tfsdk:"version"`
 	Groups                      types.List   `tfsdk:"groups"`
+	GroupNames                  types.List   `tfsdk:"group_names"`
 	SshEnabled                  types.Bool   `tfsdk:"ssh_enabled"`
 	InactivityExpirationEnabled types.Bool   `tfsdk:"inactivity_expiration_enabled"`
 	ApprovalRequired            types.Bool   `tfsdk:"approval_required"`
@@ -49,6 +55,10 @@ type PeersModel struct {
 	CityName                    types.String `tfsdk:"city_name"`
 	SerialNumber                types.String `tfsdk:"serial_number"`
 	ExtraDnsLabels              types.List   `tfsdk:"extra_dns_labels"`
+	ExcludeEphemeral            types.Bool   `tfsdk:"exclude_ephemeral"`
+	Unique                      types.Bool   `tfsdk:"unique"`
+	LastSeenBefore              types.String `tfsdk:"last_seen_before"`
+	AllPeers                    types.List   `tfsdk:"all_peers"`
 }
 
 // PeersDataSource defines the data source implementation.
@@ -62,11 +72,11 @@ func (d *PeersDataSource) Metadata(ctx context.Context, req datasource.MetadataR
 
 func (d *PeersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Read Peer information.",
+		MarkdownDescription: "Read Peer information. Peers are listed with a single request, as the NetBird Management API returns every peer of the account in one unpaginated response, so filters apply to all peers regardless of the account size. A paginated response is reported as an error instead of being read partially.",
 		Attributes: map[string]schema.Attribute{
 			"ids": schema.ListAttribute{
 				Computed:            true,
-				MarkdownDescription: "Peers IDs",
+				MarkdownDescription: "Peers IDs, sorted lexically",
 				ElementType:         types.StringType,
 			},
 			"name": schema.StringAttribute{
@@ -90,7 +100,7 @@ func (d *PeersDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:            true,
 			},
 			"last_seen": schema.StringAttribute{
-				MarkdownDescription: "Peer Last Seen timedate",
+				MarkdownDescription: "Peer Last Seen timedate, null if the peer was never seen",
 				Computed:            true,
 			},
 			"os": schema.StringAttribute{
@@ -117,6 +127,11 @@ func (d *PeersDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"group_names": schema.ListAttribute{
+				MarkdownDescription: "Peer group names, peers in all listed groups are matched. Names are resolved to group IDs with an additional Groups API request",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
 			"ssh_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Enable SSH to Peer",
 				Optional:            true,
@@ -128,7 +143,7 @@ func (d *PeersDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:            true,
 			},
 			"approval_required": schema.BoolAttribute{
-				MarkdownDescription: "Indicates whether peer needs approval",
+				MarkdownDescription: "Indicates whether peer needs approval, set to true to list the peers pending approval when peer approval is enabled",
 				Optional:            true,
 				Computed:            true,
 			},
@@ -162,7 +177,7 @@ func (d *PeersDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:            true,
 			},
 			"last_login": schema.StringAttribute{
-				MarkdownDescription: "Time of peer last login",
+				MarkdownDescription: "Time of peer last login, null if the peer never logged in",
 				Computed:            true,
 			},
 			"country_code": schema.StringAttribute{
@@ -179,12 +194,143 @@ func (d *PeersDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "Peer device serial number",
 				Computed:            true,
 			},
+			"exclude_ephemeral": schema.BoolAttribute{
+				MarkdownDescription: "Exclude peers registered with an ephemeral setup key from the results, these peers are removed automatically after being offline",
+				Optional:            true,
+			},
+			"last_seen_before": schema.StringAttribute{
+				MarkdownDescription: "Only match peers last seen before this time, either a duration before now such as `720h` for 30 days or an RFC3339 timestamp. Peers that were never seen always match",
+				Optional:            true,
+			},
+			"unique": schema.BoolAttribute{
+				MarkdownDescription: "Require the selectors to match exactly one peer, reading fails listing the matched peers otherwise",
+				Optional:            true,
+			},
 			"extra_dns_labels": schema.ListAttribute{
 				MarkdownDescription: "Peer extra DNS Labels",
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"all_peers": schema.ListNestedAttribute{
+				MarkdownDescription: "Full attributes of the matched peers, in the same order as `ids`. Every matched peer is stored in state, so narrow the selectors on large accounts to keep plans small.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Peer ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Peer Name",
+							Computed:            true,
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "Peer  IP",
+							Computed:            true,
+						},
+						"connection_ip": schema.StringAttribute{
+							MarkdownDescription: "Peer Public IP",
+							Computed:            true,
+						},
+						"connected": schema.BoolAttribute{
+							MarkdownDescription: "Peer Connection Status",
+							Computed:            true,
+						},
+						"last_seen": schema.StringAttribute{
+							MarkdownDescription: "Peer Last Seen timedate, null if the peer was never seen",
+							Computed:            true,
+						},
+						"os": schema.StringAttribute{
+							MarkdownDescription: "Peer OS",
+							Computed:            true,
+						},
+						"kernel_version": schema.StringAttribute{
+							MarkdownDescription: "Peer Kernel Version",
+							Computed:            true,
+						},
+						"geoname_id": schema.Int32Attribute{
+							MarkdownDescription: "Peer Location ID",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "Peer Version",
+							Computed:            true,
+						},
+						"groups": schema.ListAttribute{
+							MarkdownDescription: "Peer groups",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"group_names": schema.ListAttribute{
+							MarkdownDescription: "Peer group names, in the same order as `groups`",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"ssh_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Enable SSH to Peer",
+							Computed:            true,
+						},
+						"inactivity_expiration_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Enable inactivity expiration for peer",
+							Computed:            true,
+						},
+						"approval_required": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether peer needs approval",
+							Computed:            true,
+						},
+						"dns_label": schema.StringAttribute{
+							MarkdownDescription: "Peer DNS Label",
+							Computed:            true,
+						},
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "User ID of peer",
+							Computed:            true,
+						},
+						"hostname": schema.StringAttribute{
+							MarkdownDescription: "Peer's HOSTNAME",
+							Computed:            true,
+						},
+						"ui_version": schema.StringAttribute{
+							MarkdownDescription: "Peer  UI Version",
+							Computed:            true,
+						},
+						"login_expiration_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether login expiration is enabled for peer",
+							Computed:            true,
+						},
+						"login_expired": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether peer login is expired",
+							Computed:            true,
+						},
+						"last_login": schema.StringAttribute{
+							MarkdownDescription: "Time of peer last login, null if the peer never logged in",
+							Computed:            true,
+						},
+						"country_code": schema.StringAttribute{
+							MarkdownDescription: "Peer country code",
+							Computed:            true,
+						},
+						"city_name": schema.StringAttribute{
+							MarkdownDescription: "Peer city name",
+							Computed:            true,
+						},
+						"serial_number": schema.StringAttribute{
+							MarkdownDescription: "Peer device serial number",
+							Computed:            true,
+						},
+						"ephemeral": schema.BoolAttribute{
+							MarkdownDescription: "Indicates whether the peer was registered with an ephemeral setup key and is removed automatically after being offline",
+							Computed:            true,
+						},
+						"extra_dns_labels": schema.ListAttribute{
+							MarkdownDescription: "Peer extra DNS Labels",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -195,24 +341,88 @@ func (d *PeersDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+}
+
+// peersResolveGroupNames resolves group_names to a list of group IDs, returning a null list if group_names is not set.
+func peersResolveGroupNames(ctx context.Context, client *netbird.Client, groupNames types.List) (types.List, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	if groupNames.IsNull() || groupNames.IsUnknown() {
+		return types.ListNull(types.StringType), ret
+	}
+	var names []string
+	ret.Append(groupNames.ElementsAs(ctx, &names, false)...)
+	if ret.HasError() {
+		return types.ListNull(types.StringType), ret
+	}
+
+	groups, err := client.Groups.List(ctx)
+	if err != nil {
+		ret.AddError("Error listing Groups", formatAPIError(err))
+		return types.ListNull(types.StringType), ret
+	}
+	ids := make(map[string]string, len(groups))
+	for _, g := range groups {
+		ids[g.Name] = g.Id
+	}
+
+	resolved := make([]attr.Value, len(names))
+	for i, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			ret.AddAttributeError(path.Root("group_names"), "Group not found", fmt.Sprintf("No group named %s", name))
+			return types.ListNull(types.StringType), ret
+		}
+		resolved[i] = types.StringValue(id)
+	}
+	return types.ListValueMust(types.StringType, resolved), ret
+}
+
+// peersLastSeenThreshold parses last_seen_before, a duration before now or an RFC3339 timestamp.
+func peersLastSeenThreshold(s string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(s); err == nil {
+		return now.Add(-duration), nil
+	}
+	threshold, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("last_seen_before must be a duration such as 720h or an RFC3339 timestamp, found %q", s)
+	}
+	return threshold, nil
+}
+
+// matchLastSeenBefore matches peers last seen before threshold, a zero threshold matches every peer.
+func matchLastSeenBefore(lastSeen, threshold time.Time) int {
+	if threshold.IsZero() {
+		return 0
+	}
+	// Peers that were never seen have a zero last seen time, treat them as seen before any threshold
+	if lastSeen.IsZero() || lastSeen.Before(threshold) {
+		return 1
+	}
+	return -1000
 }
 
-func filterPeers(ctx context.Context, peers []api.Peer, data PeersModel) ([]string, diag.Diagnostics) {
+// filterPeers returns the sorted IDs of peers matching data, groupNameIds holds the resolved group_names
+// and lastSeenBefore the parsed last_seen_before, zero if unset.
+func filterPeers(ctx context.Context, peers []api.Peer, data PeersModel, groupNameIds types.List, lastSeenBefore time.Time) ([]string, diag.Diagnostics) {
 	var d diag.Diagnostics
 	var filteredPeers []string
 	for _, p := range peers {
+		// Ephemeral peers are transient, skip them regardless of the other selectors
+		if p.Ephemeral && data.ExcludeEphemeral.ValueBool() {
+			continue
+		}
 		match := 0
 		match += matchString(p.Name, data.Name)
 		match += matchString(p.Ip, data.Ip)
@@ -230,6 +440,7 @@ func filterPeers(ctx context.Context, peers []api.Peer, data PeersModel) ([]stri
 		match += matchBool(p.LoginExpirationEnabled, data.LoginExpirationEnabled)
 		match += matchBool(p.LoginExpired, data.LoginExpired)
 		match += matchInt32(int32(p.GeonameId), data.GeonameId)
+		match += matchLastSeenBefore(p.LastSeen, lastSeenBefore)
 		m, di := matchListString(ctx, p.ExtraDnsLabels, data.ExtraDnsLabels)
 		d.Append(di...)
 		if d.HasError() {
@@ -246,15 +457,50 @@ func filterPeers(ctx context.Context, peers []api.Peer, data PeersModel) ([]stri
 			return filteredPeers, d
 		}
 		match += m
+		m, di = matchListString(ctx, groups, groupNameIds)
+		d.Append(di...)
+		if d.HasError() {
+			return filteredPeers, d
+		}
+		match += m
 
 		if match > 0 {
 			filteredPeers = append(filteredPeers, p.Id)
 		}
 	}
 
+	// The list order of the API is not stable, sort to keep ids stable between refreshes
+	slices.Sort(filteredPeers)
 	return filteredPeers, d
 }
 
+// peersAPIToTerraform converts the peers matching ids to a list of peer objects, keeping the order of ids.
+func peersAPIToTerraform(ctx context.Context, peers []api.Peer, ids []string) (types.List, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	byID := make(map[string]*api.Peer, len(peers))
+	for i := range peers {
+		byID[peers[i].Id] = &peers[i]
+	}
+
+	models := make([]PeerModel, 0, len(ids))
+	for _, id := range ids {
+		peer, ok := byID[id]
+		if !ok {
+			continue
+		}
+		var m PeerModel
+		ret.Append(peerAPIToTerraform(ctx, peer, &m)...)
+		if ret.HasError() {
+			return types.ListNull(PeerModel{}.TFType()), ret
+		}
+		models = append(models, m)
+	}
+
+	l, d := types.ListValueFrom(ctx, PeerModel{}.TFType(), models)
+	ret.Append(d...)
+	return l, ret
+}
+
 func (d *PeersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data PeersModel
 
@@ -283,37 +529,74 @@ func (d *PeersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		data.LoginExpired,
 		data.GeonameId,
 		data.Groups,
+		data.GroupNames,
+		data.LastSeenBefore,
 	) == 0 {
 		resp.Diagnostics.AddError(
 			"No selector",
 			`Must add at least one of (name, ip, connection_ip, dns_label, user_id, hostname, country_code, city_name, os,`+
 				` connected, ssh_enabled, inactivity_expiration_enabled, approval_required, login_expiration_enabled,`+
-				` login_expired, geoname_id, groups)`,
+				` login_expired, geoname_id, groups, group_names, last_seen_before)`,
 		)
 		return
 	}
 
+	var lastSeenBefore time.Time
+	if !data.LastSeenBefore.IsNull() && !data.LastSeenBefore.IsUnknown() {
+		var err error
+		lastSeenBefore, err = peersLastSeenThreshold(data.LastSeenBefore.ValueString(), time.Now())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("last_seen_before"), "Invalid Last Seen Threshold", err.Error())
+			return
+		}
+	}
+
+	// The Management API returns all peers in a single, unpaginated response, a paginated
+	// response envelope fails to decode as a list instead of silently returning the first page
 	var err error
 	var peers []api.Peer
 	peers, err = d.client.Peers.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Peers", err.Error())
+		resp.Diagnostics.AddError("Error listing Peers", formatAPIError(err))
+		return
+	}
+
+	groupNameIds, di := peersResolveGroupNames(ctx, d.client, data.GroupNames)
+	resp.Diagnostics.Append(di...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	filteredPeers, di := filterPeers(ctx, peers, data)
+	filteredPeers, di := filterPeers(ctx, peers, data, groupNameIds, lastSeenBefore)
 	resp.Diagnostics.Append(di...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if data.Unique.ValueBool() {
+		switch {
+		case len(filteredPeers) == 0:
+			resp.Diagnostics.AddError("No match", "Peer matching parameters not found")
+			return
+		case len(filteredPeers) > 1:
+			resp.Diagnostics.AddError("Multiple Matches", fmt.Sprintf("unique is set but %d peers match: %s", len(filteredPeers), strings.Join(filteredPeers, ", ")))
+			return
+		}
+	}
+
 	data.Ids, di = types.ListValueFrom(ctx, types.StringType, filteredPeers)
 	resp.Diagnostics.Append(di...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.AllPeers, di = peersAPIToTerraform(ctx, peers, filteredPeers)
+	resp.Diagnostics.Append(di...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PeersSummaryDataSource{}
+
+func NewPeersSummaryDataSource() datasource.DataSource {
+	return &PeersSummaryDataSource{}
+}
+
+// PeersSummaryDataSource defines the data source implementation.
+type PeersSummaryDataSource struct {
+	client *netbird.Client
+}
+
+// PeersSummaryModel describes the data source data model.
+type PeersSummaryModel struct {
+	Total            types.Int32 `tfsdk:"total"`
+	Connected        types.Int32 `tfsdk:"connected"`
+	Disconnected     types.Int32 `tfsdk:"disconnected"`
+	LoginExpired     types.Int32 `tfsdk:"login_expired"`
+	ApprovalRequired types.Int32 `tfsdk:"approval_required"`
+}
+
+func (d *PeersSummaryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_peers_summary"
+}
+
+func (d *PeersSummaryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Read Peer counts of the account",
+		MarkdownDescription: "Read Peer counts of the account, without storing every peer in state, see [NetBird Docs](https://docs.netbird.io/how-to/add-machines-to-your-network) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"total": schema.Int32Attribute{
+				MarkdownDescription: "Number of peers",
+				Computed:            true,
+			},
+			"connected": schema.Int32Attribute{
+				MarkdownDescription: "Number of connected peers",
+				Computed:            true,
+			},
+			"disconnected": schema.Int32Attribute{
+				MarkdownDescription: "Number of disconnected peers",
+				Computed:            true,
+			},
+			"login_expired": schema.Int32Attribute{
+				MarkdownDescription: "Number of peers with an expired login",
+				Computed:            true,
+			},
+			"approval_required": schema.Int32Attribute{
+				MarkdownDescription: "Number of peers waiting for approval",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PeersSummaryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// peersSummary counts peers by connection, login and approval status.
+func peersSummary(peers []api.Peer) PeersSummaryModel {
+	var connected, loginExpired, approvalRequired int32
+	for _, p := range peers {
+		if p.Connected {
+			connected++
+		}
+		if p.LoginExpired {
+			loginExpired++
+		}
+		if p.ApprovalRequired {
+			approvalRequired++
+		}
+	}
+	return PeersSummaryModel{
+		Total:            types.Int32Value(int32(len(peers))),
+		Connected:        types.Int32Value(connected),
+		Disconnected:     types.Int32Value(int32(len(peers)) - connected),
+		LoginExpired:     types.Int32Value(loginExpired),
+		ApprovalRequired: types.Int32Value(approvalRequired),
+	}
+}
+
+func (d *PeersSummaryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PeersSummaryModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peers, err := d.client.Peers.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Peers", formatAPIError(err))
+		return
+	}
+
+	data = peersSummary(peers)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
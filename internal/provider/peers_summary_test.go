@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+func Test_PeersSummaryDataSource_Read(t *testing.T) {
+	peers := []api.Peer{
+		{Id: "p1", Connected: true, Groups: []api.GroupMinimum{}},
+		{Id: "p2", Connected: true, ApprovalRequired: true, Groups: []api.GroupMinimum{}},
+		{Id: "p3", LoginExpired: true, Groups: []api.GroupMinimum{}},
+		{Id: "p4", LoginExpired: true, ApprovalRequired: true, Groups: []api.GroupMinimum{}},
+		{Id: "p5", Groups: []api.GroupMinimum{}},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(peers)
+	})
+
+	d := &PeersSummaryDataSource{client: client}
+	req, resp := testDataSourceRead(t, d, &PeersSummaryModel{
+		Total:            types.Int32Null(),
+		Connected:        types.Int32Null(),
+		Disconnected:     types.Int32Null(),
+		LoginExpired:     types.Int32Null(),
+		ApprovalRequired: types.Int32Null(),
+	})
+	d.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var out PeersSummaryModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	expected := PeersSummaryModel{
+		Total:            types.Int32Value(5),
+		Connected:        types.Int32Value(2),
+		Disconnected:     types.Int32Value(3),
+		LoginExpired:     types.Int32Value(2),
+		ApprovalRequired: types.Int32Value(2),
+	}
+	if out != expected {
+		t.Fatalf("Expected:\n%#v\nFound:\n%#v", expected, out)
+	}
+}
@@ -2,9 +2,14 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand"
+	"net/http"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -55,10 +60,32 @@ func Test_filterPeers(t *testing.T) {
 			},
 			expected: []string{"p1", "p2"},
 		},
+		{
+			peers: []api.Peer{
+				{
+					Id: "p1",
+					Os: "Ubuntu 24.04",
+				},
+				{
+					Id:        "p2",
+					Os:        "Ubuntu 24.04",
+					Ephemeral: true,
+				},
+				{
+					Id: "p3",
+					Os: "Windows 11",
+				},
+			},
+			filter: PeersModel{
+				Os:               types.StringValue("Ubuntu 24.04"),
+				ExcludeEphemeral: types.BoolValue(true),
+			},
+			expected: []string{"p1"},
+		},
 	}
 
 	for _, c := range cases {
-		out, outDiag := filterPeers(context.Background(), c.peers, c.filter)
+		out, outDiag := filterPeers(context.Background(), c.peers, c.filter, types.ListNull(types.StringType), time.Time{})
 		if outDiag.HasError() {
 			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
 		}
@@ -69,6 +96,367 @@ func Test_filterPeers(t *testing.T) {
 	}
 }
 
+func Test_filterPeers_sorted(t *testing.T) {
+	peers := []api.Peer{{Id: "p3", Os: "Linux"}, {Id: "p10", Os: "Linux"}, {Id: "p1", Os: "Linux"}, {Id: "p2", Os: "Linux"}}
+	expected := []string{"p1", "p10", "p2", "p3"}
+	filter := PeersModel{Os: types.StringValue("Linux")}
+
+	for i := 0; i < 5; i++ {
+		rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+		out, outDiag := filterPeers(context.Background(), peers, filter, types.ListNull(types.StringType), time.Time{})
+		if outDiag.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+		}
+		if !slices.Equal(out, expected) {
+			t.Fatalf("Expected:\n%#v\nFound:\n%#v", expected, out)
+		}
+	}
+}
+
+func Test_PeersDataSource_Read_largeAccount(t *testing.T) {
+	peerCount := 2500
+	peers := make([]api.Peer, peerCount)
+	for i := range peers {
+		peers[i] = api.Peer{Id: fmt.Sprintf("p%d", i), Os: "Ubuntu 24.04", Groups: []api.GroupMinimum{}}
+	}
+
+	requests := 0
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(peers)
+	})
+
+	d := &PeersDataSource{client: client}
+	req, resp := testDataSourceRead(t, d, &PeersModel{
+		Ids:            types.ListNull(types.StringType),
+		Os:             types.StringValue("Ubuntu 24.04"),
+		Groups:         types.ListNull(types.StringType),
+		GroupNames:     types.ListNull(types.StringType),
+		ExtraDnsLabels: types.ListNull(types.StringType),
+		AllPeers:       types.ListNull(PeerModel{}.TFType()),
+	})
+	d.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var out PeersModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+	if len(out.Ids.Elements()) != peerCount {
+		t.Fatalf("Expected %d peers, found %d", peerCount, len(out.Ids.Elements()))
+	}
+	if requests != 1 {
+		t.Fatalf("Expected a single list request, found %d", requests)
+	}
+}
+
+func Test_PeersDataSource_Read_allPeers(t *testing.T) {
+	peers := []api.Peer{
+		{Id: "p1", Os: "Ubuntu 24.04", Groups: []api.GroupMinimum{{Id: "g1"}}},
+		{Id: "p2", Os: "Darwin 15.0", Groups: []api.GroupMinimum{{Id: "g1"}, {Id: "g2"}}},
+		{Id: "p3", Os: "Windows 11", Groups: []api.GroupMinimum{{Id: "g3"}}},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(peers)
+	})
+
+	d := &PeersDataSource{client: client}
+	req, resp := testDataSourceRead(t, d, &PeersModel{
+		Ids:            types.ListNull(types.StringType),
+		Groups:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+		GroupNames:     types.ListNull(types.StringType),
+		ExtraDnsLabels: types.ListNull(types.StringType),
+		AllPeers:       types.ListNull(PeerModel{}.TFType()),
+	})
+	d.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var out PeersModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	var allPeers []PeerModel
+	resp.Diagnostics.Append(out.AllPeers.ElementsAs(context.Background(), &allPeers, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	if len(allPeers) != 2 {
+		t.Fatalf("Expected 2 peers, found %d", len(allPeers))
+	}
+	for i, expected := range peers[:2] {
+		if allPeers[i].Id.ValueString() != expected.Id {
+			t.Fatalf("Expected peer %s at index %d, found %s", expected.Id, i, allPeers[i].Id.ValueString())
+		}
+		if allPeers[i].Os.ValueString() != expected.Os {
+			t.Fatalf("Expected peer %s os %s, found %s", expected.Id, expected.Os, allPeers[i].Os.ValueString())
+		}
+		if len(allPeers[i].Groups.Elements()) != len(expected.Groups) {
+			t.Fatalf("Expected peer %s to have %d groups, found %d", expected.Id, len(expected.Groups), len(allPeers[i].Groups.Elements()))
+		}
+	}
+}
+
+func Test_PeersDataSource_Read_pendingApproval(t *testing.T) {
+	peers := []api.Peer{
+		{Id: "p1", Name: "approved", UserId: "u1", ApprovalRequired: false, Groups: []api.GroupMinimum{}},
+		{Id: "p2", Name: "pending", UserId: "u2", ApprovalRequired: true, Groups: []api.GroupMinimum{}},
+		{Id: "p3", Name: "also-approved", UserId: "u1", ApprovalRequired: false, Groups: []api.GroupMinimum{}},
+		{Id: "p4", Name: "also-pending", UserId: "u3", ApprovalRequired: true, Groups: []api.GroupMinimum{}},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(peers)
+	})
+
+	d := &PeersDataSource{client: client}
+	req, resp := testDataSourceRead(t, d, &PeersModel{
+		Ids:              types.ListNull(types.StringType),
+		ApprovalRequired: types.BoolValue(true),
+		Groups:           types.ListNull(types.StringType),
+		GroupNames:       types.ListNull(types.StringType),
+		ExtraDnsLabels:   types.ListNull(types.StringType),
+		AllPeers:         types.ListNull(PeerModel{}.TFType()),
+	})
+	d.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var out PeersModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	var allPeers []PeerModel
+	resp.Diagnostics.Append(out.AllPeers.ElementsAs(context.Background(), &allPeers, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	expected := []api.Peer{peers[1], peers[3]}
+	if len(allPeers) != len(expected) {
+		t.Fatalf("Expected %d pending peers, found %d", len(expected), len(allPeers))
+	}
+	for i, p := range expected {
+		if allPeers[i].Id.ValueString() != p.Id || allPeers[i].Name.ValueString() != p.Name || allPeers[i].UserId.ValueString() != p.UserId {
+			t.Fatalf("Expected pending peer %s (%s, %s) at index %d, found %s (%s, %s)", p.Id, p.Name, p.UserId, i, allPeers[i].Id.ValueString(), allPeers[i].Name.ValueString(), allPeers[i].UserId.ValueString())
+		}
+	}
+}
+
+func Test_PeersDataSource_Read_groupNames(t *testing.T) {
+	peers := []api.Peer{
+		{Id: "p1", Groups: []api.GroupMinimum{{Id: "g1", Name: "devs"}}},
+		{Id: "p2", Groups: []api.GroupMinimum{{Id: "g1", Name: "devs"}, {Id: "g2", Name: "ops"}}},
+		{Id: "p3", Groups: []api.GroupMinimum{{Id: "g2", Name: "ops"}}},
+	}
+	groups := []api.Group{
+		{Id: "g1", Name: "devs"},
+		{Id: "g2", Name: "ops"},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/peers":
+			_ = json.NewEncoder(w).Encode(peers)
+		case "/api/groups":
+			_ = json.NewEncoder(w).Encode(groups)
+		default:
+			t.Fatalf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cases := []struct {
+		groupNames    []attr.Value
+		expected      []string
+		expectedError string
+	}{
+		{groupNames: []attr.Value{types.StringValue("devs")}, expected: []string{"p1", "p2"}},
+		{groupNames: []attr.Value{types.StringValue("devs"), types.StringValue("ops")}, expected: []string{"p2"}},
+		{groupNames: []attr.Value{types.StringValue("admins")}, expectedError: "Group not found"},
+	}
+	for _, c := range cases {
+		d := &PeersDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &PeersModel{
+			Ids:            types.ListNull(types.StringType),
+			Groups:         types.ListNull(types.StringType),
+			GroupNames:     types.ListValueMust(types.StringType, c.groupNames),
+			ExtraDnsLabels: types.ListNull(types.StringType),
+			AllPeers:       types.ListNull(PeerModel{}.TFType()),
+		})
+		d.Read(context.Background(), req, resp)
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out PeersModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		var ids []string
+		resp.Diagnostics.Append(out.Ids.ElementsAs(context.Background(), &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if !slices.Equal(ids, c.expected) {
+			t.Fatalf("Expected peers %v for group_names %v, found %v", c.expected, c.groupNames, ids)
+		}
+	}
+}
+
+func Test_PeersDataSource_Read_unique(t *testing.T) {
+	peers := []api.Peer{
+		{Id: "p1", Name: "web", Os: "linux", Groups: []api.GroupMinimum{}},
+		{Id: "p2", Name: "db", Os: "linux", Groups: []api.GroupMinimum{}},
+		{Id: "p3", Name: "laptop", Os: "darwin", Groups: []api.GroupMinimum{}},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(peers)
+	})
+
+	cases := []struct {
+		os            string
+		expected      []string
+		expectedError string
+	}{
+		{os: "darwin", expected: []string{"p3"}},
+		{os: "windows", expectedError: "No match"},
+		{os: "linux", expectedError: "Multiple Matches"},
+	}
+	for _, c := range cases {
+		d := &PeersDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &PeersModel{
+			Ids:            types.ListNull(types.StringType),
+			Os:             types.StringValue(c.os),
+			Unique:         types.BoolValue(true),
+			Groups:         types.ListNull(types.StringType),
+			GroupNames:     types.ListNull(types.StringType),
+			ExtraDnsLabels: types.ListNull(types.StringType),
+			AllPeers:       types.ListNull(PeerModel{}.TFType()),
+		})
+		d.Read(context.Background(), req, resp)
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error for os %s, found %v", c.expectedError, c.os, resp.Diagnostics.Errors())
+			}
+			if c.expectedError == "Multiple Matches" && !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "p1, p2") {
+				t.Fatalf("Expected matched peers p1, p2 to be listed, found %s", resp.Diagnostics.Errors()[0].Detail())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out PeersModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		var ids []string
+		resp.Diagnostics.Append(out.Ids.ElementsAs(context.Background(), &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if !slices.Equal(ids, c.expected) {
+			t.Fatalf("Expected peers %v for os %s, found %v", c.expected, c.os, ids)
+		}
+	}
+}
+
+func Test_PeersDataSource_Read_lastSeenBefore(t *testing.T) {
+	now := time.Now().UTC()
+	peers := []api.Peer{
+		{Id: "p1", Name: "recent", LastSeen: now.Add(-time.Hour), Groups: []api.GroupMinimum{}},
+		{Id: "p2", Name: "week", LastSeen: now.Add(-7 * 24 * time.Hour), Groups: []api.GroupMinimum{}},
+		{Id: "p3", Name: "stale", LastSeen: now.Add(-60 * 24 * time.Hour), Groups: []api.GroupMinimum{}},
+		{Id: "p4", Name: "never", Groups: []api.GroupMinimum{}},
+	}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(peers)
+	})
+
+	cases := []struct {
+		lastSeenBefore string
+		expected       []string
+		expectedError  string
+	}{
+		{lastSeenBefore: "720h", expected: []string{"p3", "p4"}},
+		{lastSeenBefore: "24h", expected: []string{"p2", "p3", "p4"}},
+		{lastSeenBefore: now.Add(-2 * time.Hour).Format(time.RFC3339), expected: []string{"p2", "p3", "p4"}},
+		{lastSeenBefore: "2000-01-01T00:00:00Z", expected: []string{"p4"}},
+		{lastSeenBefore: "30 days", expectedError: "Invalid Last Seen Threshold"},
+	}
+	for _, c := range cases {
+		d := &PeersDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &PeersModel{
+			Ids:            types.ListNull(types.StringType),
+			LastSeenBefore: types.StringValue(c.lastSeenBefore),
+			Groups:         types.ListNull(types.StringType),
+			GroupNames:     types.ListNull(types.StringType),
+			ExtraDnsLabels: types.ListNull(types.StringType),
+			AllPeers:       types.ListNull(PeerModel{}.TFType()),
+		})
+		d.Read(context.Background(), req, resp)
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error for last_seen_before %s, found %v", c.expectedError, c.lastSeenBefore, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out PeersModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		var ids []string
+		resp.Diagnostics.Append(out.Ids.ElementsAs(context.Background(), &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if !slices.Equal(ids, c.expected) {
+			t.Fatalf("Expected peers %v for last_seen_before %s, found %v", c.expected, c.lastSeenBefore, ids)
+		}
+	}
+}
+
+func Test_PeersDataSource_Read_paginated(t *testing.T) {
+	var pages []string
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		pages = append(pages, page)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":[{"id":"p%s","groups":[]}],"page":%s,"page_size":1,"total_pages":3,"total_records":3}`, page, page)
+	})
+
+	d := &PeersDataSource{client: client}
+	req, resp := testDataSourceRead(t, d, &PeersModel{
+		Ids:            types.ListNull(types.StringType),
+		Os:             types.StringValue("Ubuntu 24.04"),
+		Groups:         types.ListNull(types.StringType),
+		GroupNames:     types.ListNull(types.StringType),
+		ExtraDnsLabels: types.ListNull(types.StringType),
+		AllPeers:       types.ListNull(PeerModel{}.TFType()),
+	})
+	d.Read(context.Background(), req, resp)
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Error listing Peers" {
+		t.Fatalf("Expected paginated response to fail, found %v", resp.Diagnostics.Errors())
+	}
+	if !slices.Equal(pages, []string{"1"}) {
+		t.Fatalf("Expected a single list request, found pages %v", pages)
+	}
+}
+
 func Test_Peers_Create(t *testing.T) {
 	rName := "p" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "data.netbird_peers." + rName
@@ -34,7 +34,7 @@ func (d *PolicyDataSource) Metadata(ctx context.Context, req datasource.Metadata
 func (d *PolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Read Policy Settings",
-		MarkdownDescription: "Read Policy Settings, See [NetBird Docs](https://docs.netbird.io/how-to/manage-network-access#policies) for more information.",
+		MarkdownDescription: "Read Policy Settings, See [NetBird Docs](https://docs.netbird.io/how-to/manage-network-access#policies) for more information. Policies are always listed in full and filtered locally, as the Management API does not support filtering policies, unlike the `use_server_search` option of `netbird_group`.",
 
 		Blocks: map[string]schema.Block{
 			"rule": schema.ListNestedBlock{
@@ -157,18 +157,18 @@ func (d *PolicyDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -188,7 +188,7 @@ func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	policies, err := d.client.Policies.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Policies", err.Error())
+		resp.Diagnostics.AddError("Error listing Policies", formatAPIError(err))
 		return
 	}
 
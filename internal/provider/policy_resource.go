@@ -33,6 +33,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Policy{}
 var _ resource.ResourceWithImportState = &Policy{}
+var _ resource.ResourceWithConfigValidators = &Policy{}
 
 const portStringRegex = "^([0-9]{1,4}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])$"
 
@@ -45,7 +46,7 @@ type Policy struct {
 	client *netbird.Client
 }
 
-// PolicyModel describes the resource data model.
+// PolicyModel describes the policy data model shared by the resource and data source.
 type PolicyModel struct {
 	Id                  types.String `tfsdk:"id"`
 	Name                types.String `tfsdk:"name"`
@@ -55,6 +56,13 @@ type PolicyModel struct {
 	Rules               types.List   `tfsdk:"rule"`
 }
 
+// PolicyResourceModel describes the resource data model.
+type PolicyResourceModel struct {
+	PolicyModel
+	SourcePostureCheckNames types.List `tfsdk:"source_posture_check_names"`
+	ValidateGroups          types.Bool `tfsdk:"validate_groups"`
+}
+
 type PolicyRuleModel struct {
 	Id                  types.String `tfsdk:"id"`
 	Name                types.String `tfsdk:"name"`
@@ -150,8 +158,9 @@ func (r *Policy) Schema(ctx context.Context, req resource.SchemaRequest, resp *r
 							Computed:            true,
 						},
 						"name": schema.StringAttribute{
-							MarkdownDescription: "Policy Name",
-							Required:            true,
+							MarkdownDescription: "Policy Rule Name, defaults to the policy name",
+							Optional:            true,
+							Computed:            true,
 						},
 						"description": schema.StringAttribute{
 							MarkdownDescription: "Policy description",
@@ -172,7 +181,7 @@ func (r *Policy) Schema(ctx context.Context, req resource.SchemaRequest, resp *r
 							Validators:          []validator.String{stringvalidator.OneOf("tcp", "udp", "icmp", "all", "netbird-ssh")},
 						},
 						"ports": schema.ListAttribute{
-							MarkdownDescription: "Policy Rule Ports (mutually exclusive with port_ranges)",
+							MarkdownDescription: "Policy Rule Ports (mutually exclusive with port_ranges), tcp and udp rules without ports or port_ranges apply to all ports",
 							ElementType:         types.StringType,
 							Optional:            true,
 							Computed:            true,
@@ -215,11 +224,18 @@ func (r *Policy) Schema(ctx context.Context, req resource.SchemaRequest, resp *r
 							Computed:            true,
 							Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("source_resource")), listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
 						},
-						"source_resource": schema.ObjectAttribute{
+						"source_resource": schema.SingleNestedAttribute{
 							MarkdownDescription: "Policy Rule Source Resource (mutually exclusive with sources)",
-							AttributeTypes: map[string]attr.Type{
-								"id":   types.StringType,
-								"type": types.StringType,
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									MarkdownDescription: "Resource ID",
+									Required:            true,
+								},
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Resource type (domain, host, peer or subnet)",
+									Required:            true,
+									Validators:          []validator.String{stringvalidator.OneOf("domain", "host", "peer", "subnet")},
+								},
 							},
 							Optional:   true,
 							Computed:   true,
@@ -232,11 +248,18 @@ func (r *Policy) Schema(ctx context.Context, req resource.SchemaRequest, resp *r
 							Computed:            true,
 							Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("destination_resource")), listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
 						},
-						"destination_resource": schema.ObjectAttribute{
+						"destination_resource": schema.SingleNestedAttribute{
 							MarkdownDescription: "Policy Rule Destination Resource (mutually exclusive with destinations)",
-							AttributeTypes: map[string]attr.Type{
-								"id":   types.StringType,
-								"type": types.StringType,
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									MarkdownDescription: "Resource ID",
+									Required:            true,
+								},
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Resource type (domain, host, peer or subnet)",
+									Required:            true,
+									Validators:          []validator.String{stringvalidator.OneOf("domain", "host", "peer", "subnet")},
+								},
 							},
 							Optional:   true,
 							Computed:   true,
@@ -265,10 +288,10 @@ func (r *Policy) Schema(ctx context.Context, req resource.SchemaRequest, resp *r
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Policy Description",
+				MarkdownDescription: "Policy Description, an empty string when not set",
 				Optional:            true,
 				Computed:            true,
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Default:             stringdefault.StaticString(""),
 			},
 			"enabled": schema.BoolAttribute{
 				MarkdownDescription: "Policy enabled",
@@ -283,6 +306,18 @@ func (r *Policy) Schema(ctx context.Context, req resource.SchemaRequest, resp *r
 				Optional:            true,
 				Computed:            true,
 			},
+			"source_posture_check_names": schema.ListAttribute{
+				MarkdownDescription: "Names of posture checks associated with policy, resolved to IDs on create and update, Conflicts with source_posture_checks",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRoot("source_posture_checks")), listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
+			},
+			"validate_groups": schema.BoolAttribute{
+				MarkdownDescription: "Check that rule sources and destinations exist before creating or updating the policy, requires an additional API call",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -293,18 +328,18 @@ func (r *Policy) Configure(ctx context.Context, req resource.ConfigureRequest, r
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func policyAPIToTerraform(ctx context.Context, policy *api.Policy, data *PolicyModel) diag.Diagnostics {
@@ -312,11 +347,7 @@ func policyAPIToTerraform(ctx context.Context, policy *api.Policy, data *PolicyM
 	var diag diag.Diagnostics
 	data.Id = types.StringValue(*policy.Id)
 	data.Name = types.StringValue(policy.Name)
-	if policy.Description == nil || *policy.Description == "" {
-		data.Description = types.StringNull()
-	} else {
-		data.Description = types.StringValue(*policy.Description)
-	}
+	data.Description = normalizeDescription(policy.Description)
 	data.Enabled = types.BoolValue(policy.Enabled)
 	data.SourcePostureChecks, diag = types.ListValueFrom(ctx, types.StringType, policy.SourcePostureChecks)
 	ret.Append(diag...)
@@ -459,6 +490,9 @@ func policyRulesTerraformToAPI(ctx context.Context, data *PolicyModel) ([]api.Po
 			Name:          ruleName.ValueString(),
 			Protocol:      api.PolicyRuleUpdateProtocol(ruleProtocol.ValueString()),
 		}
+		if ruleName.IsNull() || ruleName.IsUnknown() {
+			rule.Name = data.Name.ValueString()
+		}
 		if v, ok := ruleObject.Attributes()["description"].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
 			rule.Description = v.ValueStringPointer()
 		}
@@ -567,8 +601,56 @@ func policyRulesTerraformToAPI(ctx context.Context, data *PolicyModel) ([]api.Po
 	return rules, ret
 }
 
+// policyRuleGroupIDs returns the source and destination group IDs of all rules.
+func policyRuleGroupIDs(rules []api.PolicyRuleUpdate) []string {
+	var ids []string
+	for _, r := range rules {
+		if r.Sources != nil {
+			ids = append(ids, *r.Sources...)
+		}
+		if r.Destinations != nil {
+			ids = append(ids, *r.Destinations...)
+		}
+	}
+	return ids
+}
+
+// policyResolvePostureCheckNames looks up the IDs of posture checks by name.
+func policyResolvePostureCheckNames(ctx context.Context, client *netbird.Client, names []string) (types.List, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	postureChecks, err := client.PostureChecks.List(ctx)
+	if err != nil {
+		ret.AddError("Error listing PostureChecks", formatAPIError(err))
+		return types.ListNull(types.StringType), ret
+	}
+
+	byName := map[string][]string{}
+	for _, pc := range postureChecks {
+		byName[pc.Name] = append(byName[pc.Name], pc.Id)
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		switch matches := byName[name]; len(matches) {
+		case 0:
+			ret.AddAttributeError(path.Root("source_posture_check_names"), "Posture Check Not Found", fmt.Sprintf("No posture check found with name %q", name))
+		case 1:
+			ids = append(ids, matches[0])
+		default:
+			ret.AddAttributeError(path.Root("source_posture_check_names"), "Ambiguous Posture Check Name", fmt.Sprintf("Posture checks %s share the name %q, use source_posture_checks instead", strings.Join(matches, ", "), name))
+		}
+	}
+	if ret.HasError() {
+		return types.ListNull(types.StringType), ret
+	}
+
+	l, d := types.ListValueFrom(ctx, types.StringType, ids)
+	ret.Append(d...)
+	return l, ret
+}
+
 func (r *Policy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data PolicyModel
+	var data PolicyResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -577,12 +659,27 @@ func (r *Policy) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
-	rules, d := policyRulesTerraformToAPI(ctx, &data)
+	rules, d := policyRulesTerraformToAPI(ctx, &data.PolicyModel)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, policyRuleGroupIDs(rules))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.SourcePostureCheckNames.IsNull() {
+		data.SourcePostureChecks, d = policyResolvePostureCheckNames(ctx, r.client, stringListDefault(ctx, data.SourcePostureCheckNames, []string{}))
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	policyReq := api.PostApiPoliciesJSONRequestBody{
 		Name:                data.Name.ValueString(),
 		Description:         data.Description.ValueStringPointer(),
@@ -593,11 +690,11 @@ func (r *Policy) Create(ctx context.Context, req resource.CreateRequest, resp *r
 
 	policy, err := r.client.Policies.Create(ctx, policyReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating policy", err.Error())
+		resp.Diagnostics.AddError("Error creating policy", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(policyAPIToTerraform(ctx, policy, &data)...)
+	resp.Diagnostics.Append(policyAPIToTerraform(ctx, policy, &data.PolicyModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -608,7 +705,7 @@ func (r *Policy) Create(ctx context.Context, req resource.CreateRequest, resp *r
 }
 
 func (r *Policy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data PolicyModel
+	var data PolicyResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -627,12 +724,12 @@ func (r *Policy) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting Policy", err.Error())
+			resp.Diagnostics.AddError("Error getting Policy", formatAPIError(err))
 		}
 		return
 	}
 
-	resp.Diagnostics.Append(policyAPIToTerraform(ctx, policy, &data)...)
+	resp.Diagnostics.Append(policyAPIToTerraform(ctx, policy, &data.PolicyModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -643,7 +740,7 @@ func (r *Policy) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 }
 
 func (r *Policy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data PolicyModel
+	var data PolicyResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -652,12 +749,27 @@ func (r *Policy) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
-	rules, d := policyRulesTerraformToAPI(ctx, &data)
+	rules, d := policyRulesTerraformToAPI(ctx, &data.PolicyModel)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, policyRuleGroupIDs(rules))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.SourcePostureCheckNames.IsNull() {
+		data.SourcePostureChecks, d = policyResolvePostureCheckNames(ctx, r.client, stringListDefault(ctx, data.SourcePostureCheckNames, []string{}))
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	policyReq := api.PolicyUpdate{
 		Name:                data.Name.ValueString(),
 		Description:         data.Description.ValueStringPointer(),
@@ -674,11 +786,11 @@ func (r *Policy) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		policy, err = r.client.Policies.Update(ctx, data.Id.ValueString(), api.PutApiPoliciesPolicyIdJSONRequestBody(policyReq))
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Policy", err.Error())
+		resp.Diagnostics.AddError("Error updating Policy", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(policyAPIToTerraform(ctx, policy, &data)...)
+	resp.Diagnostics.Append(policyAPIToTerraform(ctx, policy, &data.PolicyModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -688,7 +800,7 @@ func (r *Policy) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 }
 
 func (r *Policy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data PolicyModel
+	var data PolicyResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -698,11 +810,103 @@ func (r *Policy) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 	}
 
 	err := r.client.Policies.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting Policy", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Policy", formatAPIError(err))
 	}
 }
 
+// policyConfigValidator warns about tcp and udp rules without ports, these rules apply to all ports.
+type policyConfigValidator struct{}
+
+func (v policyConfigValidator) Description(ctx context.Context) string {
+	return "tcp and udp rules should set ports or port_ranges"
+}
+
+func (v policyConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v policyConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Rules.IsNull() || data.Rules.IsUnknown() {
+		return
+	}
+
+	var rules []PolicyRuleModel
+	resp.Diagnostics.Append(data.Rules.ElementsAs(ctx, &rules, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, rule := range rules {
+		protocol := rule.Protocol.ValueString()
+		if protocol != "tcp" && protocol != "udp" {
+			continue
+		}
+		if rule.Ports.IsNull() && rule.PortRanges.IsNull() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("rule").AtListIndex(i).AtName("protocol"),
+				"Rule Applies to All Ports",
+				fmt.Sprintf("The %s rule sets neither ports nor port_ranges and applies to all ports, set ports or port_ranges to limit it.", protocol),
+			)
+		}
+	}
+}
+
+// policyRuleEndpointsValidator requires every rule to set a source and a destination, either groups or a resource.
+type policyRuleEndpointsValidator struct{}
+
+func (v policyRuleEndpointsValidator) Description(ctx context.Context) string {
+	return "rules must set sources or source_resource, and destinations or destination_resource"
+}
+
+func (v policyRuleEndpointsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v policyRuleEndpointsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Rules.IsNull() || data.Rules.IsUnknown() {
+		return
+	}
+
+	var rules []PolicyRuleModel
+	resp.Diagnostics.Append(data.Rules.ElementsAs(ctx, &rules, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, rule := range rules {
+		if policyRuleEndpointMissing(rule.Sources, rule.SourceResource) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rule").AtListIndex(i).AtName("sources"),
+				"Invalid Configuration",
+				fmt.Sprintf(`rule[%d]: a source is required, set "sources" or "source_resource"`, i),
+			)
+		}
+		if policyRuleEndpointMissing(rule.Destinations, rule.DestinationResource) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rule").AtListIndex(i).AtName("destinations"),
+				"Invalid Configuration",
+				fmt.Sprintf(`rule[%d]: a destination is required, set "destinations" or "destination_resource"`, i),
+			)
+		}
+	}
+}
+
+// policyRuleEndpointMissing reports whether neither groups nor a resource are set, unknown values may set either.
+func policyRuleEndpointMissing(groups types.List, res types.Object) bool {
+	if groups.IsUnknown() || res.IsUnknown() || !res.IsNull() {
+		return false
+	}
+	return groups.IsNull() || len(groups.Elements()) == 0
+}
+
+func (r *Policy) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{policyConfigValidator{}, policyRuleEndpointsValidator{}}
+}
+
 func (r *Policy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("validate_groups"), false)...)
 }
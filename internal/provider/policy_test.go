@@ -2,13 +2,20 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
 	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -281,6 +288,40 @@ func Test_policyRulesTerraformToAPI(t *testing.T) {
 				},
 			},
 		},
+		{
+			resource: &PolicyModel{
+				Name: types.StringValue("single-rule-policy"),
+				Rules: types.ListValueMust(PolicyRuleModel{}.TFType(), []attr.Value{
+					types.ObjectValueMust(PolicyRuleModel{}.TFType().AttrTypes, map[string]attr.Value{
+						"id":                   types.StringNull(),
+						"action":               types.StringValue("accept"),
+						"bidirectional":        types.BoolValue(true),
+						"description":          types.StringNull(),
+						"sources":              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+						"destinations":         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g2")}),
+						"enabled":              types.BoolValue(true),
+						"name":                 types.StringUnknown(),
+						"ports":                types.ListNull(types.StringType),
+						"protocol":             types.StringValue("all"),
+						"port_ranges":          types.ListNull(PolicyRulePortRangeModel{}.TFType()),
+						"source_resource":      types.ObjectNull(PolicyRuleResourceModel{}.TFType().AttrTypes),
+						"destination_resource": types.ObjectNull(PolicyRuleResourceModel{}.TFType().AttrTypes),
+						"authorized_groups":    types.MapNull(types.ListType{ElemType: types.StringType}),
+					}),
+				}),
+			},
+			expected: []api.PolicyRuleUpdate{
+				{
+					Action:        api.PolicyRuleUpdateActionAccept,
+					Bidirectional: true,
+					Sources:       &[]string{"g1"},
+					Destinations:  &[]string{"g2"},
+					Enabled:       true,
+					Name:          "single-rule-policy",
+					Protocol:      api.PolicyRuleUpdateProtocolAll,
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -296,6 +337,68 @@ func Test_policyRulesTerraformToAPI(t *testing.T) {
 
 }
 
+func Test_policyRules_order(t *testing.T) {
+	names := []string{"zeta", "alpha", "mid"}
+	ruleValues := make([]attr.Value, len(names))
+	for i, name := range names {
+		ruleValues[i] = types.ObjectValueMust(PolicyRuleModel{}.TFType().AttrTypes, map[string]attr.Value{
+			"id":                   types.StringValue("r" + strconv.Itoa(i)),
+			"action":               types.StringValue("accept"),
+			"bidirectional":        types.BoolValue(true),
+			"description":          types.StringNull(),
+			"sources":              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+			"destinations":         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g2")}),
+			"enabled":              types.BoolValue(true),
+			"name":                 types.StringValue(name),
+			"ports":                types.ListNull(types.StringType),
+			"protocol":             types.StringValue("all"),
+			"port_ranges":          types.ListNull(PolicyRulePortRangeModel{}.TFType()),
+			"source_resource":      types.ObjectNull(PolicyRuleResourceModel{}.TFType().AttrTypes),
+			"destination_resource": types.ObjectNull(PolicyRuleResourceModel{}.TFType().AttrTypes),
+			"authorized_groups":    types.MapNull(types.ListType{ElemType: types.StringType}),
+		})
+	}
+
+	rules, d := policyRulesTerraformToAPI(context.Background(), &PolicyModel{
+		Name:  types.StringValue("policy"),
+		Rules: types.ListValueMust(PolicyRuleModel{}.TFType(), ruleValues),
+	})
+	if d.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", d.Errors())
+	}
+	policy := &api.Policy{Id: valPtr("p1"), Name: "policy", Enabled: true}
+	for i, r := range rules {
+		if r.Name != names[i] {
+			t.Fatalf("Expected rule %d to be %s in API request, found %s", i, names[i], r.Name)
+		}
+		policy.Rules = append(policy.Rules, api.PolicyRule{
+			Id:           r.Id,
+			Name:         r.Name,
+			Action:       api.PolicyRuleAction(r.Action),
+			Protocol:     api.PolicyRuleProtocol(r.Protocol),
+			Enabled:      r.Enabled,
+			Sources:      &[]api.GroupMinimum{{Id: "g1"}},
+			Destinations: &[]api.GroupMinimum{{Id: "g2"}},
+		})
+	}
+
+	var out PolicyModel
+	d = policyAPIToTerraform(context.Background(), policy, &out)
+	var outRules []PolicyRuleModel
+	d.Append(out.Rules.ElementsAs(context.Background(), &outRules, false)...)
+	if d.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", d.Errors())
+	}
+	if len(outRules) != len(names) {
+		t.Fatalf("Expected %d rules, found %d", len(names), len(outRules))
+	}
+	for i, r := range outRules {
+		if r.Name.ValueString() != names[i] {
+			t.Fatalf("Expected rule %d to be %s in state, found %s", i, names[i], r.Name.ValueString())
+		}
+	}
+}
+
 func Test_policyRulesAuthorizedGroupsValidation(t *testing.T) {
 	// authorized_groups should be rejected for non netbird-ssh protocols
 	protocols := []string{"all", "tcp", "udp", "icmp"}
@@ -357,6 +460,206 @@ func Test_policyRulesAuthorizedGroupsValidation(t *testing.T) {
 	}
 }
 
+func Test_policyRulesSourceDestinationValidation(t *testing.T) {
+	groups := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")})
+	resource := types.ObjectValueMust(PolicyRuleResourceModel{}.TFType().AttrTypes, map[string]attr.Value{
+		"id":   types.StringValue("r1"),
+		"type": types.StringValue("host"),
+	})
+	noResource := types.ObjectNull(PolicyRuleResourceModel{}.TFType().AttrTypes)
+	cases := []struct {
+		name                string
+		sources             types.List
+		sourceResource      types.Object
+		destinations        types.List
+		destinationResource types.Object
+		expectedErr         string
+	}{
+		{
+			name:                "groups",
+			sources:             groups,
+			sourceResource:      noResource,
+			destinations:        groups,
+			destinationResource: noResource,
+		},
+		{
+			name:                "resources",
+			sources:             types.ListNull(types.StringType),
+			sourceResource:      resource,
+			destinations:        types.ListNull(types.StringType),
+			destinationResource: resource,
+		},
+		{
+			name:                "missing sources",
+			sources:             types.ListValueMust(types.StringType, []attr.Value{}),
+			sourceResource:      noResource,
+			destinations:        groups,
+			destinationResource: noResource,
+			expectedErr:         `rule[0]: a source is required, set "sources" or "source_resource"`,
+		},
+		{
+			name:                "unknown sources",
+			sources:             types.ListUnknown(types.StringType),
+			sourceResource:      noResource,
+			destinations:        groups,
+			destinationResource: noResource,
+		},
+		{
+			name:                "missing destinations",
+			sources:             groups,
+			sourceResource:      noResource,
+			destinations:        types.ListNull(types.StringType),
+			destinationResource: noResource,
+			expectedErr:         `rule[0]: a destination is required, set "destinations" or "destination_resource"`,
+		},
+	}
+
+	r := &Policy{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := testResourceState(t, r, &PolicyResourceModel{
+				PolicyModel: PolicyModel{
+					Name:                types.StringValue("policy"),
+					SourcePostureChecks: types.ListNull(types.StringType),
+					Rules: types.ListValueMust(PolicyRuleModel{}.TFType(), []attr.Value{
+						types.ObjectValueMust(PolicyRuleModel{}.TFType().AttrTypes, map[string]attr.Value{
+							"id":                   types.StringNull(),
+							"action":               types.StringValue("accept"),
+							"bidirectional":        types.BoolValue(true),
+							"description":          types.StringNull(),
+							"sources":              c.sources,
+							"destinations":         c.destinations,
+							"enabled":              types.BoolValue(true),
+							"name":                 types.StringValue("test"),
+							"ports":                types.ListNull(types.StringType),
+							"protocol":             types.StringValue("all"),
+							"port_ranges":          types.ListNull(PolicyRulePortRangeModel{}.TFType()),
+							"source_resource":      c.sourceResource,
+							"destination_resource": c.destinationResource,
+							"authorized_groups":    types.MapNull(types.ListType{ElemType: types.StringType}),
+						}),
+					}),
+				},
+				SourcePostureCheckNames: types.ListNull(types.StringType),
+			})
+
+			resp := tfresource.ValidateConfigResponse{}
+			for _, v := range r.ConfigValidators(context.Background()) {
+				v.ValidateResource(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+			}
+			diags := resp.Diagnostics
+			if c.expectedErr == "" {
+				if diags.HasError() {
+					t.Fatalf("Expected no error, found %v", diags.Errors())
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Detail() != c.expectedErr {
+				t.Fatalf("Expected error %q, found %v", c.expectedErr, diags.Errors())
+			}
+		})
+	}
+}
+
+func Test_policyConfigValidator(t *testing.T) {
+	ports := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("443")})
+	portRanges := types.ListValueMust(PolicyRulePortRangeModel{}.TFType(), []attr.Value{
+		types.ObjectValueMust(PolicyRulePortRangeModel{}.TFType().AttrTypes, map[string]attr.Value{
+			"start": types.Int32Value(8000),
+			"end":   types.Int32Value(8080),
+		}),
+	})
+	noPorts := types.ListNull(types.StringType)
+	noPortRanges := types.ListNull(PolicyRulePortRangeModel{}.TFType())
+	cases := []struct {
+		name            string
+		protocol        types.String
+		ports           types.List
+		portRanges      types.List
+		expectedWarning bool
+	}{
+		{name: "tcp without ports", protocol: types.StringValue("tcp"), ports: noPorts, portRanges: noPortRanges, expectedWarning: true},
+		{name: "udp without ports", protocol: types.StringValue("udp"), ports: noPorts, portRanges: noPortRanges, expectedWarning: true},
+		{name: "tcp with ports", protocol: types.StringValue("tcp"), ports: ports, portRanges: noPortRanges},
+		{name: "udp with port ranges", protocol: types.StringValue("udp"), ports: noPorts, portRanges: portRanges},
+		{name: "tcp with unknown ports", protocol: types.StringValue("tcp"), ports: types.ListUnknown(types.StringType), portRanges: noPortRanges},
+		{name: "all without ports", protocol: types.StringValue("all"), ports: noPorts, portRanges: noPortRanges},
+		{name: "default protocol", protocol: types.StringNull(), ports: noPorts, portRanges: noPortRanges},
+	}
+
+	r := &Policy{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := testResourceState(t, r, &PolicyResourceModel{
+				PolicyModel: PolicyModel{
+					Name:                types.StringValue("policy"),
+					SourcePostureChecks: types.ListNull(types.StringType),
+					Rules: types.ListValueMust(PolicyRuleModel{}.TFType(), []attr.Value{
+						types.ObjectValueMust(PolicyRuleModel{}.TFType().AttrTypes, map[string]attr.Value{
+							"id":                   types.StringNull(),
+							"action":               types.StringNull(),
+							"bidirectional":        types.BoolNull(),
+							"description":          types.StringNull(),
+							"sources":              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+							"destinations":         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g2")}),
+							"enabled":              types.BoolNull(),
+							"name":                 types.StringNull(),
+							"ports":                c.ports,
+							"protocol":             c.protocol,
+							"port_ranges":          c.portRanges,
+							"source_resource":      types.ObjectNull(PolicyRuleResourceModel{}.TFType().AttrTypes),
+							"destination_resource": types.ObjectNull(PolicyRuleResourceModel{}.TFType().AttrTypes),
+							"authorized_groups":    types.MapNull(types.ListType{ElemType: types.StringType}),
+						}),
+					}),
+				},
+				SourcePostureCheckNames: types.ListNull(types.StringType),
+			})
+
+			resp := tfresource.ValidateConfigResponse{}
+			for _, v := range r.ConfigValidators(context.Background()) {
+				v.ValidateResource(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			if c.expectedWarning != (resp.Diagnostics.WarningsCount() == 1) || resp.Diagnostics.WarningsCount() > 1 {
+				t.Fatalf("Expected warning: %t, found %v", c.expectedWarning, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
+
+func Test_policyRuleResourceTypeValidation(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		errors       int
+	}{
+		{resourceType: "subnet", errors: 0},
+		{resourceType: "host", errors: 0},
+		{resourceType: "foobar", errors: 1},
+	}
+
+	schemaResp := tfresource.SchemaResponse{}
+	(&Policy{}).Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	ruleAttributes := schemaResp.Schema.Blocks["rule"].(schema.ListNestedBlock).NestedObject.Attributes
+	for _, name := range []string{"source_resource", "destination_resource"} {
+		typeAttribute := ruleAttributes[name].(schema.SingleNestedAttribute).Attributes["type"].(schema.StringAttribute)
+		for _, c := range cases {
+			resp := validator.StringResponse{}
+			for _, v := range typeAttribute.Validators {
+				v.ValidateString(context.Background(), validator.StringRequest{
+					Path:        path.Root("rule").AtListIndex(0).AtName(name).AtName("type"),
+					ConfigValue: types.StringValue(c.resourceType),
+				}, &resp)
+			}
+			if resp.Diagnostics.ErrorsCount() != c.errors {
+				t.Fatalf("Expected %d errors for %s type %s, found %d", c.errors, name, c.resourceType, resp.Diagnostics.ErrorsCount())
+			}
+		}
+	}
+}
+
 func Test_portRegex(t *testing.T) {
 	r := regexp.MustCompile(portStringRegex)
 	for i := range 65536 {
@@ -379,6 +682,75 @@ func Test_portRegex(t *testing.T) {
 	}
 }
 
+func Test_Policy_Create_postureCheckNames(t *testing.T) {
+	cases := []struct {
+		name          string
+		names         []string
+		expectedIDs   []string
+		expectedError string
+	}{
+		{name: "resolved", names: []string{"os", "disk"}, expectedIDs: []string{"pc2", "pc1"}},
+		{name: "missing", names: []string{"geo"}, expectedError: "Posture Check Not Found"},
+		{name: "ambiguous", names: []string{"dup"}, expectedError: "Ambiguous Posture Check Name"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var created *api.PolicyCreate
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					created = &api.PolicyCreate{}
+					_ = json.NewDecoder(r.Body).Decode(created)
+					_ = json.NewEncoder(w).Encode(api.Policy{Id: valPtr("p1"), Name: created.Name, Enabled: created.Enabled, SourcePostureChecks: *created.SourcePostureChecks, Rules: []api.PolicyRule{}})
+					return
+				}
+				_ = json.NewEncoder(w).Encode([]api.PostureCheck{{Id: "pc1", Name: "disk"}, {Id: "pc2", Name: "os"}, {Id: "pc3", Name: "dup"}, {Id: "pc4", Name: "dup"}})
+			})
+
+			names := make([]attr.Value, len(c.names))
+			for i, n := range c.names {
+				names[i] = types.StringValue(n)
+			}
+			r := &Policy{client: client}
+			plan := testResourceState(t, r, &PolicyResourceModel{
+				PolicyModel: PolicyModel{
+					Name:                types.StringValue("policy"),
+					Enabled:             types.BoolValue(true),
+					SourcePostureChecks: types.ListUnknown(types.StringType),
+					Rules:               types.ListNull(PolicyRuleModel{}.TFType()),
+				},
+				SourcePostureCheckNames: types.ListValueMust(types.StringType, names),
+				ValidateGroups:          types.BoolValue(false),
+			})
+			resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+			r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+			if c.expectedError != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+					t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+				}
+				if created != nil {
+					t.Fatalf("Expected no create request")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			if created == nil || !reflect.DeepEqual(*created.SourcePostureChecks, c.expectedIDs) {
+				t.Fatalf("Expected create request with posture checks %v, found %#v", c.expectedIDs, created)
+			}
+
+			var ids []string
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("source_posture_checks"), &ids)...)
+			if !reflect.DeepEqual(ids, c.expectedIDs) {
+				t.Fatalf("Expected source_posture_checks %v, found %v", c.expectedIDs, ids)
+			}
+		})
+	}
+}
+
 func Test_Policy_Create_Groups(t *testing.T) {
 	rName := "po" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_policy." + rName
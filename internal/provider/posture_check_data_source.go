@@ -137,18 +137,18 @@ func (d *PostureCheckDataSource) Configure(ctx context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *PostureCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -168,7 +168,7 @@ func (d *PostureCheckDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	postureChecks, err := d.client.PostureChecks.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing PostureChecks", err.Error())
+		resp.Diagnostics.AddError("Error listing PostureChecks", formatAPIError(err))
 		return
 	}
 
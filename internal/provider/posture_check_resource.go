@@ -5,19 +5,26 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 
-	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -39,7 +46,7 @@ type PostureCheck struct {
 	client *netbird.Client
 }
 
-// PostureCheckModel describes the resource data model.
+// PostureCheckModel describes the posture check data model shared by the resource and data source.
 type PostureCheckModel struct {
 	Id                    types.String `tfsdk:"id"`
 	Name                  types.String `tfsdk:"name"`
@@ -51,6 +58,12 @@ type PostureCheckModel struct {
 	ProcessCheck          types.List   `tfsdk:"process_check"`
 }
 
+// PostureCheckResourceModel describes the resource data model.
+type PostureCheckResourceModel struct {
+	PostureCheckModel
+	ManageExclusively types.Bool `tfsdk:"manage_exclusively"`
+}
+
 func (r *PostureCheck) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_posture_check"
 }
@@ -63,44 +76,65 @@ func (r *PostureCheck) Schema(ctx context.Context, req resource.SchemaRequest, r
 		Blocks: map[string]schema.Block{
 			"netbird_version_check": schema.SingleNestedBlock{
 				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Set to false to keep the check in the configuration without sending it to the API, defaults to true",
+						Optional:            true,
+					},
 					"min_version": schema.StringAttribute{
 						Optional:   true,
-						Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(version.VersionRegexpRaw), "Invalid NetBird Version")},
+						Validators: []validator.String{minVersionValidator{}},
 					},
 				},
 			},
 			"os_version_check": schema.SingleNestedBlock{
+				Validators: []validator.Object{atLeastOneAttributeValidator{attributes: append(slices.Clone(postureCheckOSVersionAttributes), "min_os_any_version")}},
 				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Set to false to keep the check in the configuration without sending it to the API, defaults to true",
+						Optional:            true,
+					},
 					"android_min_version": schema.StringAttribute{
 						Optional:   true,
-						Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(version.VersionRegexpRaw), "Invalid NetBird Version")},
+						Validators: []validator.String{minVersionValidator{}},
 					},
 					"ios_min_version": schema.StringAttribute{
 						Optional:   true,
-						Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(version.VersionRegexpRaw), "Invalid NetBird Version")},
+						Validators: []validator.String{minVersionValidator{}},
 					},
 					"darwin_min_version": schema.StringAttribute{
 						Optional:   true,
-						Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(version.VersionRegexpRaw), "Invalid NetBird Version")},
+						Validators: []validator.String{minVersionValidator{}},
 					},
 					"linux_min_kernel_version": schema.StringAttribute{
 						Optional:   true,
-						Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(version.VersionRegexpRaw), "Invalid NetBird Version")},
+						Validators: []validator.String{stringvalidator.RegexMatches(postureCheckKernelVersionRegexp, "Invalid Kernel Version")},
 					},
 					"windows_min_kernel_version": schema.StringAttribute{
 						Optional:   true,
-						Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(version.VersionRegexpRaw), "Invalid NetBird Version")},
+						Validators: []validator.String{stringvalidator.RegexMatches(postureCheckKernelVersionRegexp, "Invalid Kernel Version")},
+					},
+					// Also applied to the kernel minimums, so kernel version strings are accepted without pre-release warnings
+					"min_os_any_version": schema.StringAttribute{
+						MarkdownDescription: "Minimum version applied to every platform whose own minimum version is not set, the per-platform attributes take precedence",
+						Optional:            true,
+						Validators:          []validator.String{stringvalidator.RegexMatches(postureCheckKernelVersionRegexp, "Invalid Version")},
 					},
 				},
 			},
 			"geo_location_check": schema.SingleNestedBlock{
+				Validators: []validator.Object{objectvalidator.AlsoRequires(path.MatchRelative().AtName("locations"))},
 				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Set to false to keep the check in the configuration without sending it to the API, defaults to true",
+						Optional:            true,
+					},
 					"locations": schema.ListNestedAttribute{
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
 								"country_code": schema.StringAttribute{
-									Required:   true,
-									Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile("^[a-zA-Z]{2}$"), "country code must be 2 letters (ISO 3166-1 alpha-2 format)")},
+									MarkdownDescription: "2-letter ISO 3166-1 alpha-2 country code, sent to the API in uppercase",
+									Required:            true,
+									Validators:          []validator.String{stringvalidator.RegexMatches(regexp.MustCompile("^[a-zA-Z]{2}$"), "country code must be 2 letters (ISO 3166-1 alpha-2 format)")},
 								},
 								"city_name": schema.StringAttribute{
 									Optional: true,
@@ -111,17 +145,25 @@ func (r *PostureCheck) Schema(ctx context.Context, req resource.SchemaRequest, r
 						Validators: []validator.List{listvalidator.SizeAtLeast(1)},
 					},
 					"action": schema.StringAttribute{
-						Optional:   true,
-						Validators: []validator.String{stringvalidator.OneOf("allow", "deny")},
+						MarkdownDescription: "Set to `allow` to only allow peers in the listed locations or `deny` to block peers in the listed locations, defaults to `allow`",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(string(api.GeoLocationCheckActionAllow)),
+						Validators:          []validator.String{stringvalidator.OneOf(string(api.GeoLocationCheckActionAllow), string(api.GeoLocationCheckActionDeny))},
 					},
 				},
 			},
 			"peer_network_range_check": schema.SingleNestedBlock{
 				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Set to false to keep the check in the configuration without sending it to the API, defaults to true",
+						Optional:            true,
+					},
 					"ranges": schema.ListAttribute{
-						ElementType: types.StringType,
-						Optional:    true,
-						Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						MarkdownDescription: "IPv4 or IPv6 ranges in CIDR notation, ranges are compared in canonical form so the configured notation, e.g. `2001:DB8::/32` for `2001:db8::/32`, is kept in state",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators:          []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(cidrValidator{})},
 					},
 					"action": schema.StringAttribute{
 						Optional:   true,
@@ -130,6 +172,8 @@ func (r *PostureCheck) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"process_check": schema.ListNestedBlock{
+				// Each block is one process of a single API check, so there is no per-check enabled attribute
+				MarkdownDescription: "Processes that must run on the peer, each block adds one process to a single process check. Unlike the other check blocks it has no `enabled` attribute, remove the blocks to disable the check",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"linux_path": schema.StringAttribute{
@@ -159,9 +203,16 @@ func (r *PostureCheck) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "PostureCheck description",
+				MarkdownDescription: "PostureCheck description, an empty string when not set",
 				Optional:            true,
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"manage_exclusively": schema.BoolAttribute{
+				MarkdownDescription: "Replace all checks of the posture check with the configured ones on update. When false, checks not configured in Terraform are left as they are on the server and are not tracked in state",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
 			},
 		},
 	}
@@ -173,18 +224,43 @@ func (r *PostureCheck) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+}
+
+// postureCheckKernelVersionRegexp matches kernel versions and build numbers, which are not semantic versions,
+// e.g. `6.8.0-48-generic` on Linux or `10.0.19045` on Windows.
+var postureCheckKernelVersionRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*([-+~][0-9A-Za-z.+~_-]*)?$`)
+
+// postureCheckCountryCodes returns the country codes of the geo_location_check locations, in order.
+func postureCheckCountryCodes(geoLocationCheck types.Object) []string {
+	if geoLocationCheck.IsNull() || geoLocationCheck.IsUnknown() {
+		return nil
+	}
+	locations, ok := geoLocationCheck.Attributes()["locations"].(types.List)
+	if !ok {
+		return nil
+	}
+	var ret []string
+	for _, v := range locations.Elements() {
+		vObj, ok := v.(types.Object)
+		if !ok {
+			return ret
+		}
+		countryCode, _ := vObj.Attributes()["country_code"].(types.String)
+		ret = append(ret, countryCode.ValueString())
+	}
+	return ret
 }
 
 func postureCheckAPIToTerraform(ctx context.Context, postureCheck *api.PostureCheck, data *PostureCheckModel) diag.Diagnostics {
@@ -192,11 +268,7 @@ func postureCheckAPIToTerraform(ctx context.Context, postureCheck *api.PostureCh
 	var d diag.Diagnostics
 	data.Id = types.StringValue(postureCheck.Id)
 	data.Name = types.StringValue(postureCheck.Name)
-	if postureCheck.Description != nil {
-		data.Description = types.StringValue(*postureCheck.Description)
-	} else {
-		data.Description = types.StringNull()
-	}
+	data.Description = normalizeDescription(postureCheck.Description)
 	if postureCheck.Checks.NbVersionCheck != nil {
 		data.NetbirdVersionCheck, d = types.ObjectValueFrom(
 			ctx,
@@ -268,12 +340,18 @@ func postureCheckAPIToTerraform(ctx context.Context, postureCheck *api.PostureCh
 		}{
 			Action: string(postureCheck.Checks.GeoLocationCheck.Action),
 		}
-		for _, v := range postureCheck.Checks.GeoLocationCheck.Locations {
+		// Country codes are sent uppercase, keep the configured casing to avoid diffs
+		priorCountryCodes := postureCheckCountryCodes(data.GeoLocationCheck)
+		for i, v := range postureCheck.Checks.GeoLocationCheck.Locations {
+			countryCode := v.CountryCode
+			if i < len(priorCountryCodes) && strings.EqualFold(priorCountryCodes[i], countryCode) {
+				countryCode = priorCountryCodes[i]
+			}
 			geoValues.Locations = append(geoValues.Locations, struct {
 				CountryCode string  "tfsdk:\"country_code\""
 				CityName    *string "tfsdk:\"city_name\""
 			}{
-				CountryCode: v.CountryCode,
+				CountryCode: countryCode,
 				CityName:    v.CityName,
 			})
 		}
@@ -308,6 +386,10 @@ func postureCheckAPIToTerraform(ctx context.Context, postureCheck *api.PostureCh
 	}
 
 	if postureCheck.Checks.PeerNetworkRangeCheck != nil {
+		ranges := make([]string, len(postureCheck.Checks.PeerNetworkRangeCheck.Ranges))
+		for i, r := range postureCheck.Checks.PeerNetworkRangeCheck.Ranges {
+			ranges[i] = canonicalCIDR(r)
+		}
 		data.PeerNetworkRangeCheck, d = types.ObjectValueFrom(
 			ctx,
 			map[string]attr.Type{
@@ -318,7 +400,7 @@ func postureCheckAPIToTerraform(ctx context.Context, postureCheck *api.PostureCh
 				Ranges []string `tfsdk:"ranges"`
 				Action string   `tfsdk:"action"`
 			}{
-				Ranges: postureCheck.Checks.PeerNetworkRangeCheck.Ranges,
+				Ranges: ranges,
 				Action: string(postureCheck.Checks.PeerNetworkRangeCheck.Action),
 			},
 		)
@@ -396,7 +478,10 @@ func postureCheckTerraformToAPI(ctx context.Context, data PostureCheckModel) (ap
 			return postureCheckReq, ret
 		}
 		postureCheckReq.Checks.GeoLocationCheck = &api.GeoLocationCheck{
-			Action: api.GeoLocationCheckAction(geoLocationAction.ValueString()),
+			Action: api.GeoLocationCheckActionAllow,
+		}
+		if !geoLocationAction.IsNull() && !geoLocationAction.IsUnknown() {
+			postureCheckReq.Checks.GeoLocationCheck.Action = api.GeoLocationCheckAction(geoLocationAction.ValueString())
 		}
 		geoLocations, ok := data.GeoLocationCheck.Attributes()["locations"].(types.List)
 		if !ok {
@@ -420,7 +505,7 @@ func postureCheckTerraformToAPI(ctx context.Context, data PostureCheckModel) (ap
 				return postureCheckReq, ret
 			}
 			postureCheckReq.Checks.GeoLocationCheck.Locations = append(postureCheckReq.Checks.GeoLocationCheck.Locations, api.Location{
-				CountryCode: vCountryCode.ValueString(),
+				CountryCode: strings.ToUpper(vCountryCode.ValueString()),
 				CityName:    vCityName.ValueStringPointer(),
 			})
 		}
@@ -464,6 +549,14 @@ func postureCheckTerraformToAPI(ctx context.Context, data PostureCheckModel) (ap
 			ret.AddError("Unexpected Value", fmt.Sprintf("data.os_version_check.windows_min_kernel_version expected to be types.String, found %T", data.OSVersionCheck.Attributes()["windows_min_kernel_version"]))
 			return postureCheckReq, ret
 		}
+		// min_os_any_version is provider-side, it is only set on resources
+		if minOSAnyVersion, ok := data.OSVersionCheck.Attributes()["min_os_any_version"].(types.String); ok && !minOSAnyVersion.IsNull() && !minOSAnyVersion.IsUnknown() {
+			androidMinVersion = postureCheckOSMinVersion(androidMinVersion, minOSAnyVersion)
+			iosMinVersion = postureCheckOSMinVersion(iosMinVersion, minOSAnyVersion)
+			darwinMinVersion = postureCheckOSMinVersion(darwinMinVersion, minOSAnyVersion)
+			linuxMinKernelVersion = postureCheckOSMinVersion(linuxMinKernelVersion, minOSAnyVersion)
+			windowsMinKernelVersion = postureCheckOSMinVersion(windowsMinKernelVersion, minOSAnyVersion)
+		}
 		if !androidMinVersion.IsNull() && !androidMinVersion.IsUnknown() {
 			postureCheckReq.Checks.OsVersionCheck.Android = &api.MinVersionCheck{
 				MinVersion: androidMinVersion.ValueString(),
@@ -489,6 +582,10 @@ func postureCheckTerraformToAPI(ctx context.Context, data PostureCheckModel) (ap
 				MinKernelVersion: windowsMinKernelVersion.ValueString(),
 			}
 		}
+		// An empty check is rejected by the API, omit it entirely
+		if *postureCheckReq.Checks.OsVersionCheck == (api.OSVersionCheck{}) {
+			postureCheckReq.Checks.OsVersionCheck = nil
+		}
 	}
 
 	if !data.PeerNetworkRangeCheck.IsNull() && !data.PeerNetworkRangeCheck.IsUnknown() {
@@ -532,19 +629,225 @@ func postureCheckTerraformToAPI(ctx context.Context, data PostureCheckModel) (ap
 				ret.AddError("Unexpected Value", fmt.Sprintf("data.process_check[%d].windows_path expected to be types.String, found %T", i, vObj.Attributes()["windows_path"]))
 				return postureCheckReq, ret
 			}
+			// Paths for other platforms are omitted rather than sent empty, matching how they are read back
 			postureCheckReq.Checks.ProcessCheck.Processes = append(postureCheckReq.Checks.ProcessCheck.Processes, api.Process{
-				LinuxPath:   vLinuxPath.ValueStringPointer(),
-				MacPath:     vMacPath.ValueStringPointer(),
-				WindowsPath: vWindowsPath.ValueStringPointer(),
+				LinuxPath:   stringNonEmptyPointer(vLinuxPath),
+				MacPath:     stringNonEmptyPointer(vMacPath),
+				WindowsPath: stringNonEmptyPointer(vWindowsPath),
 			})
 		}
 	}
 
+	postureCheckOmitDisabled(postureCheckReq.Checks, data)
 	return postureCheckReq, ret
 }
 
+// postureCheckOSVersionAttributes lists the per-platform minimum versions of os_version_check.
+var postureCheckOSVersionAttributes = []string{"android_min_version", "ios_min_version", "darwin_min_version", "linux_min_kernel_version", "windows_min_kernel_version"}
+
+// postureCheckOSMinVersion returns the platform minimum version v, or anyVersion if v is not set.
+func postureCheckOSMinVersion(v, anyVersion types.String) types.String {
+	if v.IsNull() {
+		return anyVersion
+	}
+	return v
+}
+
+// postureCheckKeepOSAnyVersion adds the min_os_any_version attribute configured in prior to os_version_check read from the API,
+// platform versions set from it are kept unset as configured.
+func postureCheckKeepOSAnyVersion(ctx context.Context, data *PostureCheckModel, prior PostureCheckModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	attrTypes := maps.Clone(data.OSVersionCheck.AttributeTypes(ctx))
+	if _, ok := attrTypes["min_os_any_version"]; ok {
+		// Disabled checks are kept as configured in prior
+		return ret
+	}
+	attrTypes["min_os_any_version"] = types.StringType
+	if data.OSVersionCheck.IsNull() {
+		data.OSVersionCheck = types.ObjectNull(attrTypes)
+		return ret
+	}
+
+	anyVersion := types.StringNull()
+	priorAttrs := map[string]attr.Value{}
+	if !prior.OSVersionCheck.IsNull() && !prior.OSVersionCheck.IsUnknown() {
+		priorAttrs = prior.OSVersionCheck.Attributes()
+		if v, ok := priorAttrs["min_os_any_version"].(types.String); ok {
+			anyVersion = v
+		}
+	}
+	attrs := maps.Clone(data.OSVersionCheck.Attributes())
+	attrs["min_os_any_version"] = anyVersion
+	if !anyVersion.IsNull() && !anyVersion.IsUnknown() {
+		for _, name := range postureCheckOSVersionAttributes {
+			if v, ok := priorAttrs[name]; ok && !v.IsNull() {
+				continue
+			}
+			if v, ok := attrs[name].(types.String); ok && v.ValueString() == anyVersion.ValueString() {
+				attrs[name] = types.StringNull()
+			}
+		}
+	}
+	v, d := types.ObjectValue(attrTypes, attrs)
+	ret.Append(d...)
+	data.OSVersionCheck = v
+	return ret
+}
+
+// postureCheckKeepProviderAttributes adds the provider-side attributes configured in prior to the check blocks read from the API.
+func postureCheckKeepProviderAttributes(ctx context.Context, data *PostureCheckModel, prior PostureCheckModel) diag.Diagnostics {
+	ret := postureCheckKeepEnabled(ctx, data, prior)
+	ret.Append(postureCheckKeepOSAnyVersion(ctx, data, prior)...)
+	ret.Append(postureCheckKeepRanges(ctx, data, prior)...)
+	return ret
+}
+
+// postureCheckKeepRanges keeps the notation of the peer network ranges configured in prior
+// for ranges read from the API in canonical form.
+func postureCheckKeepRanges(ctx context.Context, data *PostureCheckModel, prior PostureCheckModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	check, priorCheck := data.PeerNetworkRangeCheck, prior.PeerNetworkRangeCheck
+	if check.IsNull() || check.IsUnknown() || priorCheck.IsNull() || priorCheck.IsUnknown() {
+		return ret
+	}
+	ranges, ok := check.Attributes()["ranges"].(types.List)
+	priorRanges, priorOk := priorCheck.Attributes()["ranges"].(types.List)
+	if !ok || !priorOk || ranges.IsNull() || ranges.IsUnknown() || priorRanges.IsNull() || priorRanges.IsUnknown() {
+		return ret
+	}
+
+	elements := slices.Clone(ranges.Elements())
+	priorElements := priorRanges.Elements()
+	for i, v := range elements {
+		if i >= len(priorElements) {
+			break
+		}
+		value, ok := v.(types.String)
+		priorValue, priorOk := priorElements[i].(types.String)
+		if ok && priorOk && !priorValue.IsNull() && !priorValue.IsUnknown() && canonicalCIDR(priorValue.ValueString()) == value.ValueString() {
+			elements[i] = priorValue
+		}
+	}
+	kept, d := types.ListValue(types.StringType, elements)
+	ret.Append(d...)
+	attrs := maps.Clone(check.Attributes())
+	attrs["ranges"] = kept
+	v, d := types.ObjectValue(check.AttributeTypes(ctx), attrs)
+	ret.Append(d...)
+	data.PeerNetworkRangeCheck = v
+	return ret
+}
+
+// postureCheckEnabledBlocks returns the check blocks supporting the provider-side enabled attribute by name.
+func postureCheckEnabledBlocks(data *PostureCheckModel) map[string]*types.Object {
+	return map[string]*types.Object{
+		"netbird_version_check":    &data.NetbirdVersionCheck,
+		"os_version_check":         &data.OSVersionCheck,
+		"geo_location_check":       &data.GeoLocationCheck,
+		"peer_network_range_check": &data.PeerNetworkRangeCheck,
+	}
+}
+
+// postureCheckDisabled reports whether a check block is configured with enabled set to false.
+func postureCheckDisabled(check types.Object) bool {
+	if check.IsNull() || check.IsUnknown() {
+		return false
+	}
+	enabled, ok := check.Attributes()["enabled"].(types.Bool)
+	return ok && !enabled.IsNull() && !enabled.IsUnknown() && !enabled.ValueBool()
+}
+
+// postureCheckOmitDisabled removes the checks disabled in data from checks.
+func postureCheckOmitDisabled(checks *api.Checks, data PostureCheckModel) {
+	if postureCheckDisabled(data.NetbirdVersionCheck) {
+		checks.NbVersionCheck = nil
+	}
+	if postureCheckDisabled(data.OSVersionCheck) {
+		checks.OsVersionCheck = nil
+	}
+	if postureCheckDisabled(data.GeoLocationCheck) {
+		checks.GeoLocationCheck = nil
+	}
+	if postureCheckDisabled(data.PeerNetworkRangeCheck) {
+		checks.PeerNetworkRangeCheck = nil
+	}
+}
+
+// postureCheckKeepEnabled adds the enabled attribute configured in prior to the check blocks read from the API,
+// disabled checks are not sent to the API so they are kept as configured in prior.
+func postureCheckKeepEnabled(ctx context.Context, data *PostureCheckModel, prior PostureCheckModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	priorBlocks := postureCheckEnabledBlocks(&prior)
+	for name, check := range postureCheckEnabledBlocks(data) {
+		priorCheck := *priorBlocks[name]
+		if postureCheckDisabled(priorCheck) {
+			*check = priorCheck
+			continue
+		}
+
+		attrTypes := maps.Clone(check.AttributeTypes(ctx))
+		attrTypes["enabled"] = types.BoolType
+		if check.IsNull() {
+			*check = types.ObjectNull(attrTypes)
+			continue
+		}
+
+		enabled := types.BoolNull()
+		if !priorCheck.IsNull() && !priorCheck.IsUnknown() {
+			if v, ok := priorCheck.Attributes()["enabled"].(types.Bool); ok {
+				enabled = v
+			}
+		}
+		attrs := maps.Clone(check.Attributes())
+		attrs["enabled"] = enabled
+		v, d := types.ObjectValue(attrTypes, attrs)
+		ret.Append(d...)
+		*check = v
+	}
+	return ret
+}
+
+// postureCheckMergeChecks fills the checks missing from update with the existing server checks.
+func postureCheckMergeChecks(update *api.Checks, existing api.Checks) {
+	if update.NbVersionCheck == nil {
+		update.NbVersionCheck = existing.NbVersionCheck
+	}
+	if update.OsVersionCheck == nil {
+		update.OsVersionCheck = existing.OsVersionCheck
+	}
+	if update.GeoLocationCheck == nil {
+		update.GeoLocationCheck = existing.GeoLocationCheck
+	}
+	if update.PeerNetworkRangeCheck == nil {
+		update.PeerNetworkRangeCheck = existing.PeerNetworkRangeCheck
+	}
+	if update.ProcessCheck == nil {
+		update.ProcessCheck = existing.ProcessCheck
+	}
+}
+
+// postureCheckKeepManagedChecks resets the checks not managed in managed to null,
+// so server-managed checks don't show up as drift.
+func postureCheckKeepManagedChecks(ctx context.Context, data *PostureCheckModel, managed PostureCheckModel) {
+	if managed.NetbirdVersionCheck.IsNull() {
+		data.NetbirdVersionCheck = types.ObjectNull(data.NetbirdVersionCheck.AttributeTypes(ctx))
+	}
+	if managed.OSVersionCheck.IsNull() {
+		data.OSVersionCheck = types.ObjectNull(data.OSVersionCheck.AttributeTypes(ctx))
+	}
+	if managed.GeoLocationCheck.IsNull() {
+		data.GeoLocationCheck = types.ObjectNull(data.GeoLocationCheck.AttributeTypes(ctx))
+	}
+	if managed.PeerNetworkRangeCheck.IsNull() {
+		data.PeerNetworkRangeCheck = types.ObjectNull(data.PeerNetworkRangeCheck.AttributeTypes(ctx))
+	}
+	if managed.ProcessCheck.IsNull() || len(managed.ProcessCheck.Elements()) == 0 {
+		data.ProcessCheck = types.ListNull(data.ProcessCheck.ElementType(ctx))
+	}
+}
+
 func (r *PostureCheck) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data PostureCheckModel
+	var data PostureCheckResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -553,7 +856,7 @@ func (r *PostureCheck) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	postureCheckReq, d := postureCheckTerraformToAPI(ctx, data)
+	postureCheckReq, d := postureCheckTerraformToAPI(ctx, data.PostureCheckModel)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -561,11 +864,13 @@ func (r *PostureCheck) Create(ctx context.Context, req resource.CreateRequest, r
 
 	postureCheck, err := r.client.PostureChecks.Create(ctx, postureCheckReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating postureCheck", err.Error())
+		resp.Diagnostics.AddError("Error creating postureCheck", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(postureCheckAPIToTerraform(ctx, postureCheck, &data)...)
+	planned := data.PostureCheckModel
+	resp.Diagnostics.Append(postureCheckAPIToTerraform(ctx, postureCheck, &data.PostureCheckModel)...)
+	resp.Diagnostics.Append(postureCheckKeepProviderAttributes(ctx, &data.PostureCheckModel, planned)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -575,8 +880,44 @@ func (r *PostureCheck) Create(ctx context.Context, req resource.CreateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// postureCheckSupportedChecks lists the API names of the checks postureCheckAPIToTerraform maps to the schema.
+var postureCheckSupportedChecks = []string{"geo_location_check", "nb_version_check", "os_version_check", "peer_network_range_check", "process_check"}
+
+// postureCheckGet reads a posture check, along with the sorted names of checks set on it that the provider does not support.
+func postureCheckGet(ctx context.Context, client *netbird.Client, id string) (*api.PostureCheck, []string, error) {
+	resp, err := client.NewRequest(ctx, http.MethodGet, "/api/posture-checks/"+id, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var postureCheck api.PostureCheck
+	if err := json.Unmarshal(body, &postureCheck); err != nil {
+		return nil, nil, err
+	}
+	var raw struct {
+		Checks map[string]json.RawMessage `json:"checks"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	var unsupported []string
+	for name, check := range raw.Checks {
+		if !slices.Contains(postureCheckSupportedChecks, name) && string(check) != "null" {
+			unsupported = append(unsupported, name)
+		}
+	}
+	slices.Sort(unsupported)
+	return &postureCheck, unsupported, nil
+}
+
 func (r *PostureCheck) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data PostureCheckModel
+	var data PostureCheckResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -585,19 +926,32 @@ func (r *PostureCheck) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	postureCheck, err := r.client.PostureChecks.Get(ctx, data.Id.ValueString())
+	postureCheck, unsupported, err := postureCheckGet(ctx, r.client, data.Id.ValueString())
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting PostureCheck", err.Error())
+			resp.Diagnostics.AddError("Error getting PostureCheck", formatAPIError(err))
 		}
 		return
 	}
+	if len(unsupported) > 0 {
+		resp.Diagnostics.AddWarning("Unsupported Posture Checks", fmt.Sprintf("Posture check %s contains checks %s which are not supported by this provider version and are not stored in state, updating the posture check with manage_exclusively enabled removes them", postureCheck.Id, strings.Join(unsupported, ", ")))
+	}
+
+	managed := data.PostureCheckModel
+	resp.Diagnostics.Append(postureCheckAPIToTerraform(ctx, postureCheck, &data.PostureCheckModel)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	resp.Diagnostics.Append(postureCheckAPIToTerraform(ctx, postureCheck, &data)...)
+	if !data.ManageExclusively.ValueBool() {
+		postureCheckKeepManagedChecks(ctx, &data.PostureCheckModel, managed)
+	}
 
+	resp.Diagnostics.Append(postureCheckKeepProviderAttributes(ctx, &data.PostureCheckModel, managed)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -607,7 +961,7 @@ func (r *PostureCheck) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *PostureCheck) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data PostureCheckModel
+	var data PostureCheckResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -621,19 +975,40 @@ func (r *PostureCheck) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	postureCheckReq, d := postureCheckTerraformToAPI(ctx, data)
+	postureCheckReq, d := postureCheckTerraformToAPI(ctx, data.PostureCheckModel)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if !data.ManageExclusively.ValueBool() {
+		existing, err := r.client.PostureChecks.Get(ctx, data.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error getting PostureCheck", formatAPIError(err))
+			return
+		}
+		postureCheckMergeChecks(postureCheckReq.Checks, existing.Checks)
+		// Disabled checks are removed from the server even when they are not managed exclusively
+		postureCheckOmitDisabled(postureCheckReq.Checks, data.PostureCheckModel)
+	}
+
 	postureCheck, err := r.client.PostureChecks.Update(ctx, data.Id.ValueString(), postureCheckReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating PostureCheck", err.Error())
+		resp.Diagnostics.AddError("Error updating PostureCheck", formatAPIError(err))
+		return
+	}
+
+	managed := data.PostureCheckModel
+	resp.Diagnostics.Append(postureCheckAPIToTerraform(ctx, postureCheck, &data.PostureCheckModel)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(postureCheckAPIToTerraform(ctx, postureCheck, &data)...)
+	if !data.ManageExclusively.ValueBool() {
+		postureCheckKeepManagedChecks(ctx, &data.PostureCheckModel, managed)
+	}
+
+	resp.Diagnostics.Append(postureCheckKeepProviderAttributes(ctx, &data.PostureCheckModel, managed)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -643,7 +1018,7 @@ func (r *PostureCheck) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *PostureCheck) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data PostureCheckModel
+	var data PostureCheckResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -653,11 +1028,12 @@ func (r *PostureCheck) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	err := r.client.PostureChecks.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting PostureCheck", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting PostureCheck", formatAPIError(err))
 	}
 }
 
 func (r *PostureCheck) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("manage_exclusively"), true)...)
 }
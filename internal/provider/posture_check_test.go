@@ -2,12 +2,24 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -119,7 +131,7 @@ func Test_postureCheckAPIToTerraform(t *testing.T) {
 					"action": types.StringType,
 				}, map[string]attr.Value{
 					"action": types.StringValue("allow"),
-					"ranges": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("1.1.1.1/24")}),
+					"ranges": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("1.1.1.0/24")}),
 				}),
 				ProcessCheck: types.ListValueMust(types.ObjectType{
 					AttrTypes: map[string]attr.Type{
@@ -293,6 +305,800 @@ func Test_postureCheckTerraformToAPI(t *testing.T) {
 	}
 }
 
+func Test_postureCheckVersionValidation(t *testing.T) {
+	cases := []struct {
+		block    string
+		attr     string
+		version  string
+		warnings int
+		errors   int
+	}{
+		{block: "netbird_version_check", attr: "min_version", version: "0.40.0"},
+		{block: "netbird_version_check", attr: "min_version", version: "0.40.0-rc1", warnings: 1},
+		{block: "netbird_version_check", attr: "min_version", version: "0.40.0rc1", errors: 1},
+		{block: "os_version_check", attr: "android_min_version", version: "15"},
+		{block: "os_version_check", attr: "android_min_version", version: "15.0.0-beta", warnings: 1},
+		{block: "os_version_check", attr: "min_os_any_version", version: "6.8.0-48-generic"},
+		{block: "os_version_check", attr: "min_os_any_version", version: "10.0.0-beta"},
+		{block: "os_version_check", attr: "min_os_any_version", version: "latest", errors: 1},
+	}
+
+	r := &PostureCheck{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		block := schemaResp.Schema.Blocks[c.block].(schema.SingleNestedBlock)
+		resp := validator.StringResponse{}
+		for _, v := range block.Attributes[c.attr].(schema.StringAttribute).Validators {
+			v.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root(c.block).AtName(c.attr),
+				ConfigValue: types.StringValue(c.version),
+			}, &resp)
+		}
+		if resp.Diagnostics.WarningsCount() != c.warnings {
+			t.Fatalf("Expected %d warnings for %s %s, found %v", c.warnings, c.attr, c.version, resp.Diagnostics.Warnings())
+		}
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for %s %s, found %v", c.errors, c.attr, c.version, resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func Test_postureCheckKernelVersionValidation(t *testing.T) {
+	cases := []struct {
+		attr    string
+		version string
+		valid   bool
+	}{
+		{attr: "linux_min_kernel_version", version: "6.8.0-48-generic", valid: true},
+		{attr: "linux_min_kernel_version", version: "5.15.0-1034-azure", valid: true},
+		{attr: "linux_min_kernel_version", version: "6.1", valid: true},
+		{attr: "linux_min_kernel_version", version: "generic-6.8", valid: false},
+		{attr: "linux_min_kernel_version", version: "6.8.0 generic", valid: false},
+		{attr: "windows_min_kernel_version", version: "10.0.19045", valid: true},
+		{attr: "windows_min_kernel_version", version: "10.0.22631.4460", valid: true},
+		{attr: "windows_min_kernel_version", version: "build 19045", valid: false},
+	}
+
+	r := &PostureCheck{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	osBlock := schemaResp.Schema.Blocks["os_version_check"].(schema.SingleNestedBlock)
+	for _, c := range cases {
+		resp := validator.StringResponse{}
+		for _, v := range osBlock.Attributes[c.attr].(schema.StringAttribute).Validators {
+			v.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("os_version_check").AtName(c.attr),
+				ConfigValue: types.StringValue(c.version),
+			}, &resp)
+		}
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Fatalf("Expected %s %q valid=%t, found %v", c.attr, c.version, c.valid, resp.Diagnostics.Errors())
+		}
+	}
+}
+
+func Test_postureCheckRangesValidation(t *testing.T) {
+	cases := []struct {
+		ranges []string
+		errors int
+	}{
+		{ranges: []string{"15.160.0.0/16", "10.0.0.1/32", "2001:db8::/32"}, errors: 0},
+		{ranges: []string{"15.160.0.0/16", "15.160.0/16"}, errors: 1},
+		{ranges: []string{"15.160.0.0"}, errors: 1},
+		{ranges: []string{"15.160.0.0/33", "2001:DB8::/32"}, errors: 1},
+	}
+
+	v := listvalidator.ValueStringsAre(cidrValidator{})
+	for _, c := range cases {
+		resp := validator.ListResponse{}
+		v.ValidateList(context.Background(), validator.ListRequest{
+			Path:        path.Root("peer_network_range_check").AtName("ranges"),
+			ConfigValue: types.ListValueMust(types.StringType, toStringValues(c.ranges)),
+		}, &resp)
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for %v, found %d", c.errors, c.ranges, resp.Diagnostics.ErrorsCount())
+		}
+	}
+}
+
+func Test_postureCheckOSVersionValidation(t *testing.T) {
+	osAttrTypes := map[string]attr.Type{
+		"enabled":                    types.BoolType,
+		"android_min_version":        types.StringType,
+		"ios_min_version":            types.StringType,
+		"darwin_min_version":         types.StringType,
+		"linux_min_kernel_version":   types.StringType,
+		"windows_min_kernel_version": types.StringType,
+		"min_os_any_version":         types.StringType,
+	}
+	cases := []struct {
+		linuxVersion types.String
+		enabled      types.Bool
+		errors       int
+	}{
+		{linuxVersion: types.StringValue("6.1.0"), enabled: types.BoolNull(), errors: 0},
+		{linuxVersion: types.StringUnknown(), enabled: types.BoolNull(), errors: 0},
+		{linuxVersion: types.StringNull(), enabled: types.BoolNull(), errors: 1},
+		{linuxVersion: types.StringNull(), enabled: types.BoolValue(true), errors: 1},
+	}
+
+	r := &PostureCheck{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	validators := schemaResp.Schema.Blocks["os_version_check"].(schema.SingleNestedBlock).Validators
+	for _, c := range cases {
+		osVersionCheck := types.ObjectValueMust(osAttrTypes, map[string]attr.Value{
+			"enabled":                    c.enabled,
+			"android_min_version":        types.StringNull(),
+			"ios_min_version":            types.StringNull(),
+			"darwin_min_version":         types.StringNull(),
+			"linux_min_kernel_version":   c.linuxVersion,
+			"windows_min_kernel_version": types.StringNull(),
+			"min_os_any_version":         types.StringNull(),
+		})
+		resp := validator.ObjectResponse{}
+		for _, v := range validators {
+			v.ValidateObject(context.Background(), validator.ObjectRequest{
+				Path:        path.Root("os_version_check"),
+				ConfigValue: osVersionCheck,
+			}, &resp)
+		}
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for %s, found %d", c.errors, c.linuxVersion, resp.Diagnostics.ErrorsCount())
+		}
+		if c.errors > 0 && strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "enabled") {
+			t.Fatalf("Expected enabled not to be offered as a platform, found %s", resp.Diagnostics.Errors()[0].Detail())
+		}
+
+		if c.errors == 0 {
+			continue
+		}
+		out, outDiag := postureCheckTerraformToAPI(context.Background(), PostureCheckModel{
+			Name:                  types.StringValue("PC"),
+			NetbirdVersionCheck:   types.ObjectNull(map[string]attr.Type{}),
+			OSVersionCheck:        osVersionCheck,
+			GeoLocationCheck:      types.ObjectNull(map[string]attr.Type{}),
+			PeerNetworkRangeCheck: types.ObjectNull(map[string]attr.Type{}),
+			ProcessCheck:          types.ListNull(types.ObjectType{}),
+		})
+		if outDiag.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+		}
+		if out.Checks.OsVersionCheck != nil {
+			t.Fatalf("Expected empty os_version_check to be omitted, found %#v", out.Checks.OsVersionCheck)
+		}
+	}
+}
+
+func Test_postureCheckMinOSAnyVersion(t *testing.T) {
+	osAttrTypes := map[string]attr.Type{
+		"enabled":                    types.BoolType,
+		"android_min_version":        types.StringType,
+		"ios_min_version":            types.StringType,
+		"darwin_min_version":         types.StringType,
+		"linux_min_kernel_version":   types.StringType,
+		"windows_min_kernel_version": types.StringType,
+		"min_os_any_version":         types.StringType,
+	}
+	model := PostureCheckModel{
+		Id:                  types.StringValue("pc1"),
+		Name:                types.StringValue("PC"),
+		Description:         types.StringValue(""),
+		NetbirdVersionCheck: types.ObjectNull(map[string]attr.Type{}),
+		OSVersionCheck: types.ObjectValueMust(osAttrTypes, map[string]attr.Value{
+			"enabled":                    types.BoolNull(),
+			"android_min_version":        types.StringNull(),
+			"ios_min_version":            types.StringNull(),
+			"darwin_min_version":         types.StringNull(),
+			"linux_min_kernel_version":   types.StringValue("6.1"),
+			"windows_min_kernel_version": types.StringNull(),
+			"min_os_any_version":         types.StringValue("10.0.0"),
+		}),
+		GeoLocationCheck:      types.ObjectNull(map[string]attr.Type{}),
+		PeerNetworkRangeCheck: types.ObjectNull(map[string]attr.Type{}),
+		ProcessCheck:          types.ListNull(types.ObjectType{}),
+	}
+
+	out, outDiag := postureCheckTerraformToAPI(context.Background(), model)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", outDiag.Errors())
+	}
+	expected := &api.OSVersionCheck{
+		Android: &api.MinVersionCheck{MinVersion: "10.0.0"},
+		Ios:     &api.MinVersionCheck{MinVersion: "10.0.0"},
+		Darwin:  &api.MinVersionCheck{MinVersion: "10.0.0"},
+		Linux:   &api.MinKernelVersionCheck{MinKernelVersion: "6.1"},
+		Windows: &api.MinKernelVersionCheck{MinKernelVersion: "10.0.0"},
+	}
+	if !reflect.DeepEqual(out.Checks.OsVersionCheck, expected) {
+		t.Fatalf("Expected os_version_check %#v, found %#v", expected, out.Checks.OsVersionCheck)
+	}
+
+	// Reading back keeps the platforms set from min_os_any_version unset, as configured
+	var read PostureCheckModel
+	outDiag = postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{Id: "pc1", Name: "PC", Checks: api.Checks{OsVersionCheck: expected}}, &read)
+	outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &read, model)...)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", outDiag.Errors())
+	}
+	if !read.OSVersionCheck.Equal(model.OSVersionCheck) {
+		t.Fatalf("Expected os_version_check %s, found %s", model.OSVersionCheck, read.OSVersionCheck)
+	}
+}
+
+func Test_postureCheckTerraformToAPI_singlePlatformProcess(t *testing.T) {
+	processType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"linux_path":   types.StringType,
+		"mac_path":     types.StringType,
+		"windows_path": types.StringType,
+	}}
+	cases := []struct {
+		name    string
+		macPath types.String
+	}{
+		{name: "null paths", macPath: types.StringNull()},
+		{name: "empty paths", macPath: types.StringValue("")},
+	}
+
+	for _, c := range cases {
+		out, outDiag := postureCheckTerraformToAPI(context.Background(), PostureCheckModel{
+			Name:                  types.StringValue("PC"),
+			NetbirdVersionCheck:   types.ObjectNull(map[string]attr.Type{}),
+			OSVersionCheck:        types.ObjectNull(map[string]attr.Type{}),
+			GeoLocationCheck:      types.ObjectNull(map[string]attr.Type{}),
+			PeerNetworkRangeCheck: types.ObjectNull(map[string]attr.Type{}),
+			ProcessCheck: types.ListValueMust(processType, []attr.Value{
+				types.ObjectValueMust(processType.AttrTypes, map[string]attr.Value{
+					"linux_path":   types.StringValue("/usr/bin/netbird"),
+					"mac_path":     c.macPath,
+					"windows_path": types.StringValue(""),
+				}),
+			}),
+		})
+		if outDiag.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+		}
+
+		process := out.Checks.ProcessCheck.Processes[0]
+		if process.LinuxPath == nil || *process.LinuxPath != "/usr/bin/netbird" {
+			t.Fatalf("Expected linux_path /usr/bin/netbird for %s, found %v", c.name, process.LinuxPath)
+		}
+		if process.MacPath != nil || process.WindowsPath != nil {
+			t.Fatalf("Expected mac_path and windows_path to be omitted for %s, found %v and %v", c.name, process.MacPath, process.WindowsPath)
+		}
+	}
+}
+
+func Test_postureCheckTerraformToAPI_geoAction(t *testing.T) {
+	locationType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"country_code": types.StringType,
+		"city_name":    types.StringType,
+	}}
+	cases := []struct {
+		action   types.String
+		expected api.GeoLocationCheckAction
+	}{
+		{action: types.StringNull(), expected: api.GeoLocationCheckActionAllow},
+		{action: types.StringValue("allow"), expected: api.GeoLocationCheckActionAllow},
+		{action: types.StringValue("deny"), expected: api.GeoLocationCheckActionDeny},
+	}
+
+	for _, c := range cases {
+		out, outDiag := postureCheckTerraformToAPI(context.Background(), PostureCheckModel{
+			Name:                types.StringValue("PC"),
+			NetbirdVersionCheck: types.ObjectNull(map[string]attr.Type{}),
+			OSVersionCheck:      types.ObjectNull(map[string]attr.Type{}),
+			GeoLocationCheck: types.ObjectValueMust(map[string]attr.Type{
+				"locations": types.ListType{ElemType: locationType},
+				"action":    types.StringType,
+			}, map[string]attr.Value{
+				"locations": types.ListValueMust(locationType, []attr.Value{
+					types.ObjectValueMust(locationType.AttrTypes, map[string]attr.Value{
+						"country_code": types.StringValue("DE"),
+						"city_name":    types.StringNull(),
+					}),
+				}),
+				"action": c.action,
+			}),
+			PeerNetworkRangeCheck: types.ObjectNull(map[string]attr.Type{}),
+			ProcessCheck:          types.ListNull(types.ObjectType{}),
+		})
+		if outDiag.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+		}
+		if out.Checks.GeoLocationCheck.Action != c.expected {
+			t.Fatalf("Expected action %s for %s, found %s", c.expected, c.action, out.Checks.GeoLocationCheck.Action)
+		}
+	}
+}
+
+func Test_postureCheckGeoLocationsValidation(t *testing.T) {
+	var model PostureCheckModel
+	outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{
+		Id:   "pc1",
+		Name: "PC",
+		Checks: api.Checks{
+			GeoLocationCheck: &api.GeoLocationCheck{
+				Action:    api.GeoLocationCheckActionAllow,
+				Locations: []api.Location{{CountryCode: "DE"}},
+			},
+		},
+	}, &model)
+	outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &model, model)...)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+	}
+	geoAttrTypes := model.GeoLocationCheck.AttributeTypes(context.Background())
+	locationsType := geoAttrTypes["locations"].(types.ListType)
+
+	cases := []struct {
+		name      string
+		locations types.List
+		errors    int
+	}{
+		{name: "locations", locations: model.GeoLocationCheck.Attributes()["locations"].(types.List), errors: 0},
+		{name: "empty locations", locations: types.ListValueMust(locationsType.ElemType, []attr.Value{}), errors: 1},
+		{name: "missing locations", locations: types.ListNull(locationsType.ElemType), errors: 1},
+	}
+
+	r := &PostureCheck{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	geoBlock := schemaResp.Schema.Blocks["geo_location_check"].(schema.SingleNestedBlock)
+	for _, c := range cases {
+		model.GeoLocationCheck = types.ObjectValueMust(geoAttrTypes, map[string]attr.Value{
+			"action":    types.StringValue("allow"),
+			"locations": c.locations,
+			"enabled":   types.BoolNull(),
+		})
+		state := testResourceState(t, r, &PostureCheckResourceModel{PostureCheckModel: model, ManageExclusively: types.BoolValue(true)})
+		config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+		var diags diag.Diagnostics
+		for _, v := range geoBlock.Validators {
+			resp := validator.ObjectResponse{}
+			v.ValidateObject(context.Background(), validator.ObjectRequest{
+				Path:           path.Root("geo_location_check"),
+				PathExpression: path.MatchRoot("geo_location_check"),
+				ConfigValue:    model.GeoLocationCheck,
+				Config:         config,
+			}, &resp)
+			diags.Append(resp.Diagnostics...)
+		}
+		for _, v := range geoBlock.Attributes["locations"].(schema.ListNestedAttribute).Validators {
+			resp := validator.ListResponse{}
+			v.ValidateList(context.Background(), validator.ListRequest{
+				Path:           path.Root("geo_location_check").AtName("locations"),
+				PathExpression: path.MatchRoot("geo_location_check").AtName("locations"),
+				ConfigValue:    c.locations,
+				Config:         config,
+			}, &resp)
+			diags.Append(resp.Diagnostics...)
+		}
+		if diags.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for %s, found %v", c.errors, c.name, diags.Errors())
+		}
+	}
+}
+
+func Test_postureCheckCountryCodeNormalization(t *testing.T) {
+	geoCheck := func(countryCode string) *api.PostureCheck {
+		return &api.PostureCheck{
+			Id:   "pc1",
+			Name: "PC",
+			Checks: api.Checks{
+				GeoLocationCheck: &api.GeoLocationCheck{
+					Action:    api.GeoLocationCheckActionAllow,
+					Locations: []api.Location{{CountryCode: countryCode}},
+				},
+			},
+		}
+	}
+
+	// Configured lowercase country code
+	var model PostureCheckModel
+	outDiag := postureCheckAPIToTerraform(context.Background(), geoCheck("eg"), &model)
+	outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &model, model)...)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", outDiag.Errors())
+	}
+
+	req, outDiag := postureCheckTerraformToAPI(context.Background(), model)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", outDiag.Errors())
+	}
+	if sent := req.Checks.GeoLocationCheck.Locations[0].CountryCode; sent != "EG" {
+		t.Fatalf("Expected country code EG to be sent, found %s", sent)
+	}
+
+	cases := []struct {
+		name     string
+		prior    PostureCheckModel
+		expected string
+	}{
+		{name: "configured casing kept", prior: model, expected: "eg"},
+		{name: "import", prior: PostureCheckModel{}, expected: "EG"},
+	}
+	for _, c := range cases {
+		out := c.prior
+		outDiag := postureCheckAPIToTerraform(context.Background(), geoCheck("EG"), &out)
+		if outDiag.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", outDiag.Errors())
+		}
+		codes := postureCheckCountryCodes(out.GeoLocationCheck)
+		if len(codes) != 1 || codes[0] != c.expected {
+			t.Fatalf("Expected country code %s for %s, found %v", c.expected, c.name, codes)
+		}
+	}
+}
+
+func Test_postureCheckAPIToTerraform_canonicalRanges(t *testing.T) {
+	var out PostureCheckModel
+	outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{
+		Id:   "pc1",
+		Name: "PC",
+		Checks: api.Checks{
+			PeerNetworkRangeCheck: &api.PeerNetworkRangeCheck{
+				Action: api.PeerNetworkRangeCheckActionDeny,
+				Ranges: []string{"15.160.0.0/16", "2001:0DB8:0000::/32", "10.1.2.3/16"},
+			},
+		},
+	}, &out)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+	}
+
+	expected := types.ListValueMust(types.StringType, toStringValues([]string{"15.160.0.0/16", "2001:db8::/32", "10.1.0.0/16"}))
+	if ranges := out.PeerNetworkRangeCheck.Attributes()["ranges"]; !ranges.Equal(expected) {
+		t.Fatalf("Expected:\n%s\nFound:\n%s", expected, ranges)
+	}
+}
+
+func Test_PostureCheck_Update_manageExclusively(t *testing.T) {
+	existing := api.PostureCheck{
+		Id:   "pc1",
+		Name: "PC",
+		Checks: api.Checks{
+			NbVersionCheck: &api.MinVersionCheck{MinVersion: "0.40.0"},
+			PeerNetworkRangeCheck: &api.PeerNetworkRangeCheck{
+				Action: api.PeerNetworkRangeCheckActionDeny,
+				Ranges: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name              string
+		manageExclusively bool
+		expectNbVersion   bool
+	}{
+		{name: "exclusive", manageExclusively: true, expectNbVersion: false},
+		{name: "merge", manageExclusively: false, expectNbVersion: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var updated api.PostureCheckUpdate
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPut {
+					if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+						t.Fatalf("Failed to decode update request: %v", err)
+					}
+					_ = json.NewEncoder(w).Encode(api.PostureCheck{Id: "pc1", Name: updated.Name, Checks: *updated.Checks})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(existing)
+			})
+
+			// Only the peer network range check is configured
+			var model PostureCheckModel
+			outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{
+				Id:   "pc1",
+				Name: "PC",
+				Checks: api.Checks{
+					PeerNetworkRangeCheck: &api.PeerNetworkRangeCheck{
+						Action: api.PeerNetworkRangeCheckActionAllow,
+						Ranges: []string{"192.168.0.0/16"},
+					},
+				},
+			}, &model)
+			outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &model, model)...)
+			if outDiag.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+			}
+
+			r := &PostureCheck{client: client}
+			plan := testResourceState(t, r, &PostureCheckResourceModel{
+				PostureCheckModel: model,
+				ManageExclusively: types.BoolValue(c.manageExclusively),
+			})
+			resp := tfresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema}}
+			r.Update(context.Background(), tfresource.UpdateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+
+			if (updated.Checks.NbVersionCheck != nil) != c.expectNbVersion {
+				t.Fatalf("Expected netbird_version_check sent: %t, found %#v", c.expectNbVersion, updated.Checks.NbVersionCheck)
+			}
+			if updated.Checks.PeerNetworkRangeCheck == nil || updated.Checks.PeerNetworkRangeCheck.Ranges[0] != "192.168.0.0/16" {
+				t.Fatalf("Expected configured peer_network_range_check to be sent, found %#v", updated.Checks.PeerNetworkRangeCheck)
+			}
+
+			var out PostureCheckResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			// Server-managed checks are never tracked in state
+			if !out.NetbirdVersionCheck.IsNull() {
+				t.Fatalf("Expected netbird_version_check to be null in state, found %s", out.NetbirdVersionCheck)
+			}
+		})
+	}
+}
+
+func Test_PostureCheck_Update_enabled(t *testing.T) {
+	cases := []struct {
+		name              string
+		enabled           types.Bool
+		manageExclusively bool
+		expectNbVersion   bool
+	}{
+		{name: "enabled", enabled: types.BoolValue(true), manageExclusively: true, expectNbVersion: true},
+		{name: "enabled by default", enabled: types.BoolNull(), manageExclusively: true, expectNbVersion: true},
+		{name: "disabled", enabled: types.BoolValue(false), manageExclusively: true, expectNbVersion: false},
+		{name: "disabled without exclusive management", enabled: types.BoolValue(false), manageExclusively: false, expectNbVersion: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var updated api.PostureCheckUpdate
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPut {
+					if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+						t.Fatalf("Failed to decode update request: %v", err)
+					}
+					_ = json.NewEncoder(w).Encode(api.PostureCheck{Id: "pc1", Name: updated.Name, Checks: *updated.Checks})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(api.PostureCheck{Id: "pc1", Name: "PC", Checks: api.Checks{NbVersionCheck: &api.MinVersionCheck{MinVersion: "0.30.0"}}})
+			})
+
+			var model PostureCheckModel
+			outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{
+				Id:   "pc1",
+				Name: "PC",
+				Checks: api.Checks{
+					NbVersionCheck: &api.MinVersionCheck{MinVersion: "0.40.0"},
+					PeerNetworkRangeCheck: &api.PeerNetworkRangeCheck{
+						Action: api.PeerNetworkRangeCheckActionAllow,
+						Ranges: []string{"192.168.0.0/16"},
+					},
+				},
+			}, &model)
+			outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &model, model)...)
+			if outDiag.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+			}
+			model.NetbirdVersionCheck = types.ObjectValueMust(model.NetbirdVersionCheck.AttributeTypes(context.Background()), map[string]attr.Value{
+				"min_version": types.StringValue("0.40.0"),
+				"enabled":     c.enabled,
+			})
+
+			r := &PostureCheck{client: client}
+			plan := testResourceState(t, r, &PostureCheckResourceModel{
+				PostureCheckModel: model,
+				ManageExclusively: types.BoolValue(c.manageExclusively),
+			})
+			resp := tfresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema}}
+			r.Update(context.Background(), tfresource.UpdateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+
+			if (updated.Checks.NbVersionCheck != nil) != c.expectNbVersion {
+				t.Fatalf("Expected netbird_version_check sent: %t, found %#v", c.expectNbVersion, updated.Checks.NbVersionCheck)
+			}
+			if updated.Checks.PeerNetworkRangeCheck == nil {
+				t.Fatalf("Expected enabled peer_network_range_check to be sent")
+			}
+
+			// The check is kept in state as planned, including when it is disabled
+			var out PostureCheckResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			if !out.NetbirdVersionCheck.Equal(model.NetbirdVersionCheck) {
+				t.Fatalf("Expected netbird_version_check %s in state, found %s", model.NetbirdVersionCheck, out.NetbirdVersionCheck)
+			}
+		})
+	}
+}
+
+func Test_PostureCheck_Read_unsupportedChecks(t *testing.T) {
+	cases := []struct {
+		name             string
+		checks           string
+		expectedWarnings int
+	}{
+		{name: "supported", checks: `{"nb_version_check":{"min_version":"0.40.0"},"process_check":null}`, expectedWarnings: 0},
+		{name: "unsupported", checks: `{"nb_version_check":{"min_version":"0.40.0"},"edr_check":{"enabled":true},"av_check":{}}`, expectedWarnings: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"pc1","name":"PC","checks":` + c.checks + `}`))
+			})
+
+			var model PostureCheckModel
+			outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{Id: "pc1", Name: "PC"}, &model)
+			outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &model, model)...)
+			if outDiag.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+			}
+
+			r := &PostureCheck{client: client}
+			state := testResourceState(t, r, &PostureCheckResourceModel{
+				PostureCheckModel: model,
+				ManageExclusively: types.BoolValue(true),
+			})
+			resp := tfresource.ReadResponse{State: state}
+			r.Read(context.Background(), tfresource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+			}
+			if resp.Diagnostics.WarningsCount() != c.expectedWarnings {
+				t.Fatalf("Expected %d warnings, found %v", c.expectedWarnings, resp.Diagnostics.Warnings())
+			}
+			if c.expectedWarnings > 0 && !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "av_check, edr_check") {
+				t.Fatalf("Expected warning to list the unsupported checks, found %s", resp.Diagnostics.Warnings()[0].Detail())
+			}
+
+			var out PostureCheckResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+			if out.NetbirdVersionCheck.IsNull() {
+				t.Fatalf("Expected supported checks to be kept in state")
+			}
+		})
+	}
+}
+
+func Test_PostureCheck_Read_nullDescription(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"pc1","name":"PC","checks":{"nb_version_check":{"min_version":"0.40.0"}}}`))
+	})
+
+	var model PostureCheckModel
+	outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{Id: "pc1", Name: "PC"}, &model)
+	outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &model, model)...)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+	}
+	// State written before description defaulted to an empty string
+	model.Description = types.StringNull()
+
+	r := &PostureCheck{client: client}
+	state := testResourceState(t, r, &PostureCheckResourceModel{
+		PostureCheckModel: model,
+		ManageExclusively: types.BoolValue(true),
+	})
+	resp := tfresource.ReadResponse{State: state}
+	r.Read(context.Background(), tfresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	var out PostureCheckResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	if !out.Description.Equal(types.StringValue("")) {
+		t.Fatalf("Expected empty description in state, found %s", out.Description)
+	}
+}
+
+func Test_PostureCheck_Create_nameOnly(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"pc1","name":"PC","description":"","checks":{}}`))
+	})
+
+	r := &PostureCheck{client: client}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+
+	// Only name is configured, every check block is planned as null
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+	}
+	diags := plan.SetAttribute(context.Background(), path.Root("name"), types.StringValue("PC"))
+	diags.Append(plan.SetAttribute(context.Background(), path.Root("id"), types.StringUnknown())...)
+	if diags.HasError() {
+		t.Fatalf("Failed to build plan: %v", diags.Errors())
+	}
+
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	// State.Set above rejects values not matching the schema, check the converted values directly as well
+	var model PostureCheckModel
+	outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{Id: "pc1", Name: "PC"}, &model)
+	outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &model, model)...)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", outDiag.Errors())
+	}
+	checks := map[string]attr.Value{
+		"netbird_version_check":    model.NetbirdVersionCheck,
+		"os_version_check":         model.OSVersionCheck,
+		"geo_location_check":       model.GeoLocationCheck,
+		"peer_network_range_check": model.PeerNetworkRangeCheck,
+		"process_check":            model.ProcessCheck,
+	}
+	for name, v := range checks {
+		if !v.IsNull() {
+			t.Fatalf("Expected %s to be null, found %s", name, v)
+		}
+		expected, di := schemaResp.Schema.TypeAtPath(context.Background(), path.Root(name))
+		if di.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", di.Errors())
+		}
+		if !v.Type(context.Background()).Equal(expected) {
+			t.Fatalf("Expected %s to be of type %s, found %s", name, expected, v.Type(context.Background()))
+		}
+
+		var stateValue attr.Value
+		resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root(name), &stateValue)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if !stateValue.IsNull() {
+			t.Fatalf("Expected %s to be null in state, found %s", name, stateValue)
+		}
+	}
+}
+
+func Test_postureCheckKeepRanges(t *testing.T) {
+	var read, prior PostureCheckModel
+	outDiag := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{
+		Id:   "pc1",
+		Name: "PC",
+		Checks: api.Checks{
+			PeerNetworkRangeCheck: &api.PeerNetworkRangeCheck{
+				Action: api.PeerNetworkRangeCheckActionDeny,
+				Ranges: []string{"15.160.0.0/16", "2001:db8::/32", "10.1.0.0/16", "192.168.0.0/16"},
+			},
+		},
+	}, &read)
+	outDiag.Append(postureCheckKeepProviderAttributes(context.Background(), &read, read)...)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+	}
+
+	prior = read
+	attrs := maps.Clone(read.PeerNetworkRangeCheck.Attributes())
+	attrs["ranges"] = types.ListValueMust(types.StringType, toStringValues([]string{"15.160.0.0/16", "2001:DB8:0::/32", "10.0.0.0/8", "192.168.1.0/16"}))
+	prior.PeerNetworkRangeCheck = types.ObjectValueMust(read.PeerNetworkRangeCheck.AttributeTypes(context.Background()), attrs)
+
+	outDiag = postureCheckKeepProviderAttributes(context.Background(), &read, prior)
+	if outDiag.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
+	}
+	expected := types.ListValueMust(types.StringType, toStringValues([]string{"15.160.0.0/16", "2001:DB8:0::/32", "10.1.0.0/16", "192.168.1.0/16"}))
+	if ranges := read.PeerNetworkRangeCheck.Attributes()["ranges"]; !ranges.Equal(expected) {
+		t.Fatalf("Expected:\n%s\nFound:\n%s", expected, ranges)
+	}
+}
+
 func Test_PostureCheck_Create(t *testing.T) {
 	rName := "pc" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_posture_check." + rName
@@ -429,6 +1235,138 @@ func Test_PostureCheck_Update(t *testing.T) {
 	})
 }
 
+func Test_PostureCheck_Create_geoDeny(t *testing.T) {
+	rName := "pc" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_posture_check." + rName
+	checkAction := func(action string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			pCheckID := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+			pCheck, err := testClient().PostureChecks.Get(context.Background(), pCheckID)
+			if err != nil {
+				return err
+			}
+			if pCheck.Checks.GeoLocationCheck == nil {
+				return fmt.Errorf("Expected geo_location_check on posture check %s", pCheckID)
+			}
+			return matchPairs(map[string][]any{
+				"geo_location_check.action": {action, string(pCheck.Checks.GeoLocationCheck.Action)},
+			})
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: rName,
+				Config:       testPostureCheckGeoResource(rName, `action = "deny"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "geo_location_check.action", "deny"),
+					checkAction("deny"),
+				),
+			},
+			{
+				ResourceName: rName,
+				Config:       testPostureCheckGeoResource(rName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "geo_location_check.action", "allow"),
+					checkAction("allow"),
+				),
+			},
+		},
+	})
+}
+
+func Test_PostureCheck_Create_singleCheck(t *testing.T) {
+	// Each check block of the posture check and whether it is set on the API posture check
+	checks := map[string]func(api.Checks) bool{
+		"netbird_version_check":    func(c api.Checks) bool { return c.NbVersionCheck != nil },
+		"os_version_check":         func(c api.Checks) bool { return c.OsVersionCheck != nil },
+		"geo_location_check":       func(c api.Checks) bool { return c.GeoLocationCheck != nil },
+		"peer_network_range_check": func(c api.Checks) bool { return c.PeerNetworkRangeCheck != nil },
+		"process_check":            func(c api.Checks) bool { return c.ProcessCheck != nil },
+	}
+	cases := []struct {
+		block     string
+		config    string
+		attribute string
+		value     string
+	}{
+		{block: "netbird_version_check", config: `min_version = "0.25.0"`, attribute: "min_version", value: "0.25.0"},
+		{block: "os_version_check", config: `linux_min_kernel_version = "5.15"`, attribute: "linux_min_kernel_version", value: "5.15"},
+		{block: "geo_location_check", config: `locations = [{ country_code = "DE" }]`, attribute: "locations.0.country_code", value: "DE"},
+		{block: "peer_network_range_check", config: "ranges = [\"10.0.0.0/8\"]\n    action = \"allow\"", attribute: "ranges.0", value: "10.0.0.0/8"},
+		{block: "process_check", config: `linux_path = "/usr/bin/netbird"`, attribute: "linux_path", value: "/usr/bin/netbird"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.block, func(t *testing.T) {
+			rName := "pc" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+			rNameFull := "netbird_posture_check." + rName
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testEnsureManagementRunning(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						ResourceName: rName,
+						Config:       testPostureCheckSingleResource(rName, c.block, c.config),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr(rNameFull, c.block+"."+c.attribute, c.value),
+							func(s *terraform.State) error {
+								for name := range s.RootModule().Resources[rNameFull].Primary.Attributes {
+									block, _, _ := strings.Cut(name, ".")
+									if _, ok := checks[block]; ok && block != c.block {
+										return fmt.Errorf("Expected %s to be null in state, found %s", block, name)
+									}
+								}
+								return nil
+							},
+							func(s *terraform.State) error {
+								pCheckID := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+								pCheck, err := testClient().PostureChecks.Get(context.Background(), pCheckID)
+								if err != nil {
+									return err
+								}
+								for block, present := range checks {
+									if present(pCheck.Checks) != (block == c.block) {
+										return fmt.Errorf("Expected %s to be set only when configured on posture check %s, found %t", block, pCheckID, present(pCheck.Checks))
+									}
+								}
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testPostureCheckSingleResource(rName, block, config string) string {
+	return fmt.Sprintf(`resource "netbird_posture_check" "%s" {
+  name = "%s"
+
+  %s {
+    %s
+  }
+}`, rName, rName, block, config)
+}
+
+func testPostureCheckGeoResource(rName, action string) string {
+	return fmt.Sprintf(`resource "netbird_posture_check" "%s" {
+  name = "%s"
+
+  geo_location_check {
+    locations = [
+      {
+        country_code = "DE"
+      }
+    ]
+    %s
+  }
+}`, rName, rName, action)
+}
+
 func testPostureCheckResource(rName, desc, nbVersion, andVersion, iosVersion, macVersion, linuxVersion, winVersion, country, city, geoAction, netRange, netRangeAction, processLinux, processWindows, processMac string) string {
 	return fmt.Sprintf(`resource "netbird_posture_check" "%s" {
   name        = "%s"
@@ -3,17 +3,37 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/time/rate"
 
 	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
 )
@@ -22,6 +42,24 @@ import (
 var _ provider.Provider = &NetBirdProvider{}
 var _ provider.ProviderWithFunctions = &NetBirdProvider{}
 var _ provider.ProviderWithEphemeralResources = &NetBirdProvider{}
+var _ provider.ProviderWithValidateConfig = &NetBirdProvider{}
+
+const defaultManagementURL = "https://api.netbird.io"
+
+// defaultAPIBasePath is the path the NetBird client serves the Management API under.
+const defaultAPIBasePath = "/api"
+
+// Deployment kinds of the Management API, some settings are only available on NetBird Cloud.
+const (
+	deploymentCloud      = "cloud"
+	deploymentSelfHosted = "self-hosted"
+)
+
+const (
+	defaultNotFoundRetries   = 3
+	notFoundRetryInterval    = 500 * time.Millisecond
+	notFoundRetryMaxInterval = 4 * time.Second
+)
 
 type NetBirdProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -30,11 +68,233 @@ type NetBirdProvider struct {
 	version string
 }
 
+// providerData is passed from the provider Configure to resources and data sources.
+type providerData struct {
+	client *netbird.Client
+
+	// deployment is the deployment kind of the configured Management API.
+	deployment string
+
+	// defaultAutoGroups are assigned to setup keys not configuring auto_groups.
+	defaultAutoGroups []string
+
+	// setupKeyNameTemplate derives the names of created setup keys, nil to use configured names unchanged.
+	setupKeyNameTemplate *template.Template
+}
+
 // NetBirdProviderModel describes the provider data model.
 type NetBirdProviderModel struct {
-	ManagementURL types.String `tfsdk:"management_url"`
-	Token         types.String `tfsdk:"token"`
-	TenantAccount types.String `tfsdk:"tenant_account"`
+	ManagementURL         types.String  `tfsdk:"management_url"`
+	Token                 types.String  `tfsdk:"token"`
+	TenantAccount         types.String  `tfsdk:"tenant_account"`
+	CACert                types.String  `tfsdk:"ca_cert"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int32   `tfsdk:"max_concurrent_requests"`
+	NotFoundRetries       types.Int32   `tfsdk:"not_found_retries"`
+	APIBasePath           types.String  `tfsdk:"api_base_path"`
+	UserAgentSuffix       types.String  `tfsdk:"user_agent_suffix"`
+	Deployment            types.String  `tfsdk:"deployment"`
+	DefaultAutoGroups     types.List    `tfsdk:"default_auto_groups"`
+	SetupKeyNameTemplate  types.String  `tfsdk:"setup_key_name_template"`
+}
+
+// providerConfig holds the provider settings after applying environment variable fallbacks.
+type providerConfig struct {
+	ManagementURL string
+	Token         string
+	TenantAccount string
+	CACert        string
+	// RequestsPerSecond limits the request rate, 0 is unlimited
+	RequestsPerSecond float64
+	// MaxConcurrentRequests limits the number of requests in flight, 0 is unlimited
+	MaxConcurrentRequests int
+	// NotFoundRetries is the number of retries for reads of newly created objects, 0 disables retries
+	NotFoundRetries int
+	// APIBasePath is the path the Management API is served under on ManagementURL
+	APIBasePath string
+	// UserAgentSuffix is appended to the default User-Agent
+	UserAgentSuffix string
+	// Deployment is the configured deployment kind, empty to infer it from ManagementURL
+	Deployment string
+	// DefaultAutoGroups are the auto groups of setup keys not configuring auto_groups
+	DefaultAutoGroups []string
+	// SetupKeyNameTemplate is the text/template setup key names are derived from, empty to use configured names
+	SetupKeyNameTemplate string
+}
+
+// deployment returns the configured deployment kind, management URLs other than NetBird Cloud are assumed to be self-hosted.
+func (c providerConfig) deployment() string {
+	if c.Deployment != "" {
+		return c.Deployment
+	}
+	if u, err := url.Parse(c.ManagementURL); err == nil && u.Host == strings.TrimPrefix(defaultManagementURL, "https://") {
+		return deploymentCloud
+	}
+	return deploymentSelfHosted
+}
+
+// rateLimitedTransport delays requests to stay within the limiter's rate.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// concurrencyLimitedTransport limits the number of requests in flight, a request holds its slot until its response body is read to the end or closed.
+type concurrencyLimitedTransport struct {
+	base http.RoundTripper
+	sem  chan struct{}
+}
+
+func (t *concurrencyLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	var once sync.Once
+	release := func() { once.Do(func() { <-t.sem }) }
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releasingReadCloser{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingReadCloser calls release once the wrapped body is read to the end or closed.
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+}
+
+// Read releases once reading fails or reaches the end of the body, as the netbird client reads but never closes the body of error responses.
+func (b *releasingReadCloser) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.release()
+	}
+	return n, err
+}
+
+func (b *releasingReadCloser) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// basePathTransport moves requests from the default API base path to basePath.
+type basePathTransport struct {
+	base     http.RoundTripper
+	from     string
+	basePath string
+}
+
+func (t *basePathTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rest, ok := strings.CutPrefix(req.URL.Path, t.from); ok {
+		req = req.Clone(req.Context())
+		req.URL.Path = t.basePath + rest
+		req.URL.RawPath = ""
+	}
+	return t.base.RoundTrip(req)
+}
+
+// notFoundRetryTransport retries reads of objects created through it that the API reports as not found,
+// as newly created objects may not be returned by the Management API right away. Objects are forgotten
+// once they were read successfully or deleted, so only objects not yet seen by a read are kept.
+type notFoundRetryTransport struct {
+	base        http.RoundTripper
+	retries     int
+	interval    time.Duration
+	maxInterval time.Duration
+
+	mu      sync.Mutex
+	created map[string]struct{}
+}
+
+func (t *notFoundRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	objectPath := strings.TrimSuffix(req.URL.Path, "/")
+	switch {
+	case req.Method == http.MethodPost && resp.StatusCode < 300:
+		return t.recordCreated(objectPath, resp)
+	case req.Method == http.MethodDelete && resp.StatusCode < 300:
+		t.forget(objectPath)
+	case req.Method == http.MethodGet && resp.StatusCode < 300:
+		t.forget(objectPath)
+	case req.Method == http.MethodGet && resp.StatusCode == http.StatusNotFound && t.isCreated(objectPath):
+		attempts := 0
+		err = pollUntil(req.Context(), t.interval, t.maxInterval, func() (bool, error) {
+			if attempts > 0 {
+				_ = resp.Body.Close()
+				var err error
+				if resp, err = t.base.RoundTrip(req); err != nil {
+					resp = nil
+					return false, err
+				}
+			}
+			attempts++
+			return resp.StatusCode != http.StatusNotFound || attempts > t.retries, nil
+		})
+		if err != nil {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			return nil, err
+		}
+		if resp.StatusCode < 300 {
+			t.forget(objectPath)
+		}
+	}
+
+	return resp, nil
+}
+
+// recordCreated remembers the path of the object created by a POST request to collectionPath.
+func (t *notFoundRetryTransport) recordCreated(collectionPath string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var object struct {
+		Id string `json:"id"`
+	}
+	if json.Unmarshal(body, &object) != nil || object.Id == "" {
+		return resp, nil
+	}
+
+	t.mu.Lock()
+	t.created[collectionPath+"/"+object.Id] = struct{}{}
+	t.mu.Unlock()
+	return resp, nil
+}
+
+func (t *notFoundRetryTransport) isCreated(objectPath string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.created[objectPath]
+	return ok
+}
+
+// forget stops retrying reads of the object at objectPath.
+func (t *notFoundRetryTransport) forget(objectPath string) {
+	t.mu.Lock()
+	delete(t.created, objectPath)
+	t.mu.Unlock()
 }
 
 func (p *NetBirdProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,23 +307,174 @@ func (p *NetBirdProvider) Schema(ctx context.Context, req provider.SchemaRequest
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"management_url": schema.StringAttribute{
-				MarkdownDescription: "NetBird Management API URL, can be also set through NB_MANAGEMENT_URL Environment Variable, value defined in Terraform files takes precedence",
+				MarkdownDescription: "NetBird Management API URL, can be also set through NETBIRD_MANAGEMENT_URL (or NB_MANAGEMENT_URL) Environment Variable, value defined in Terraform files takes precedence, defaults to `" + defaultManagementURL + "`",
 				Optional:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "Admin PAT for NetBird Management Server, can be also set through NB_PAT Environment Variable, value defined in Terraform files takes precedence",
+				MarkdownDescription: "Admin PAT for NetBird Management Server, can be also set through NETBIRD_TOKEN (or NB_PAT) Environment Variable, value defined in Terraform files takes precedence",
 				Optional:            true,
 				Sensitive:           true,
 			},
 			"tenant_account": schema.StringAttribute{
-				MarkdownDescription: "Account ID to impersonate, can be also set through NB_ACCOUNT Environment Variable, value defined in Terraform files takes precedence",
+				MarkdownDescription: "Account ID to impersonate, all requests target this account instead of the default account of the token, the account must be accessible with the token, which is checked with one additional request listing the accounts when the provider is configured, can be also set through NB_ACCOUNT Environment Variable, value defined in Terraform files takes precedence",
+				Optional:            true,
+			},
+			"ca_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificate used to verify the NetBird Management API, can be also set through NETBIRD_CA_CERT Environment Variable, value defined in Terraform files takes precedence",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests per second sent to the NetBird Management API, requests over the limit wait for their turn, unlimited if not set",
+				Optional:            true,
+				Validators:          []validator.Float64{float64validator.AtLeast(0.01)},
+			},
+			"max_concurrent_requests": schema.Int32Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the NetBird Management API at the same time, requests over the limit wait for a running request to finish, unlimited if not set",
+				Optional:            true,
+				Validators:          []validator.Int32{int32validator.AtLeast(1)},
+			},
+			"not_found_retries": schema.Int32Attribute{
+				MarkdownDescription: "Number of times a resource created in the same run is read again when the NetBird Management API reports it as not found, retries back off exponentially with jitter from about half a second up to 4 seconds, `0` disables retries, defaults to `3`",
+				Optional:            true,
+				Validators:          []validator.Int32{int32validator.AtLeast(0)},
+			},
+			"api_base_path": schema.StringAttribute{
+				MarkdownDescription: "Path the NetBird Management API is served under on `management_url`, for self-hosted deployments behind a path prefix, defaults to `" + defaultAPIBasePath + "`",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must start with /")},
+			},
+			"deployment": schema.StringAttribute{
+				MarkdownDescription: "Kind of NetBird deployment, `cloud` or `self-hosted`, used to warn about settings that have no effect on self-hosted management servers, defaults to `cloud` for `" + defaultManagementURL + "` and `self-hosted` otherwise",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.OneOf(deploymentCloud, deploymentSelfHosted)},
+			},
+			"default_auto_groups": schema.ListAttribute{
+				MarkdownDescription: "Group IDs assigned to peers registering with `netbird_setup_key` resources that do not set `auto_groups`, setting `auto_groups` on a setup key overrides them. Only applied when creating setup keys",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          []validator.List{listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
+			},
+			"setup_key_name_template": schema.StringAttribute{
+				MarkdownDescription: "Go [text/template](https://pkg.go.dev/text/template) deriving the name of `netbird_setup_key` resources created on the server from their configured `name`, available as `{{.Name}}`, e.g. `env-{{.Name}}`. State keeps the configured name in `name` and the derived name in `server_name`. Only applied when creating setup keys, changing the template does not replace existing setup keys",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Appended to the `terraform-provider-netbird/<version>` User-Agent sent with every request, to identify the automation in NetBird, can be also set through NETBIRD_USER_AGENT_SUFFIX Environment Variable, value defined in Terraform files takes precedence",
 				Optional:            true,
 			},
 		},
 	}
 }
 
-func (p *NetBirdProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+// lookupEnv returns the value of the first environment variable in keys that is set.
+func lookupEnv(keys ...string) (string, bool) {
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// resolveProviderConfig applies environment variable fallbacks to the provider configuration,
+// explicit configuration takes precedence over environment variables, which take precedence over defaults.
+func resolveProviderConfig(data NetBirdProviderModel) (providerConfig, diag.Diagnostics) {
+	var ret diag.Diagnostics
+	cfg := providerConfig{
+		ManagementURL:   defaultManagementURL,
+		NotFoundRetries: defaultNotFoundRetries,
+		APIBasePath:     defaultAPIBasePath,
+	}
+
+	if !data.ManagementURL.IsUnknown() && !data.ManagementURL.IsNull() {
+		cfg.ManagementURL = data.ManagementURL.ValueString()
+	} else if v, ok := lookupEnv("NETBIRD_MANAGEMENT_URL", "NB_MANAGEMENT_URL"); ok {
+		cfg.ManagementURL = v
+	}
+
+	if err := validateManagementURL(cfg.ManagementURL); err != nil {
+		ret.AddAttributeError(path.Root("management_url"), "Invalid Management URL", formatAPIError(err))
+	}
+
+	if !data.Token.IsUnknown() && !data.Token.IsNull() {
+		cfg.Token = data.Token.ValueString()
+	} else if v, ok := lookupEnv("NETBIRD_TOKEN", "NB_PAT"); ok {
+		cfg.Token = v
+	}
+	if cfg.Token == "" {
+		ret.AddAttributeError(path.Root("token"), "Missing required argument", `The argument "token" is required, but was not set. Set it in the provider configuration or through the NETBIRD_TOKEN or NB_PAT environment variables.`)
+	}
+
+	if !data.TenantAccount.IsUnknown() && !data.TenantAccount.IsNull() {
+		cfg.TenantAccount = data.TenantAccount.ValueString()
+	} else if v, ok := lookupEnv("NB_ACCOUNT"); ok {
+		cfg.TenantAccount = v
+	}
+
+	if !data.CACert.IsUnknown() && !data.CACert.IsNull() {
+		cfg.CACert = data.CACert.ValueString()
+	} else if v, ok := lookupEnv("NETBIRD_CA_CERT"); ok {
+		cfg.CACert = v
+	}
+
+	if !data.RequestsPerSecond.IsUnknown() && !data.RequestsPerSecond.IsNull() {
+		cfg.RequestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	}
+
+	if !data.MaxConcurrentRequests.IsUnknown() && !data.MaxConcurrentRequests.IsNull() {
+		cfg.MaxConcurrentRequests = int(data.MaxConcurrentRequests.ValueInt32())
+	}
+
+	if !data.NotFoundRetries.IsUnknown() && !data.NotFoundRetries.IsNull() {
+		cfg.NotFoundRetries = int(data.NotFoundRetries.ValueInt32())
+	}
+
+	if !data.APIBasePath.IsUnknown() && !data.APIBasePath.IsNull() {
+		cfg.APIBasePath = strings.TrimSuffix(data.APIBasePath.ValueString(), "/")
+	}
+
+	if !data.Deployment.IsUnknown() && !data.Deployment.IsNull() {
+		cfg.Deployment = data.Deployment.ValueString()
+	}
+
+	if !data.DefaultAutoGroups.IsUnknown() && !data.DefaultAutoGroups.IsNull() {
+		for _, v := range data.DefaultAutoGroups.Elements() {
+			if group, ok := v.(types.String); ok && !group.IsNull() && !group.IsUnknown() {
+				cfg.DefaultAutoGroups = append(cfg.DefaultAutoGroups, group.ValueString())
+			}
+		}
+	}
+
+	if !data.SetupKeyNameTemplate.IsUnknown() && !data.SetupKeyNameTemplate.IsNull() {
+		cfg.SetupKeyNameTemplate = data.SetupKeyNameTemplate.ValueString()
+	}
+
+	if !data.UserAgentSuffix.IsUnknown() && !data.UserAgentSuffix.IsNull() {
+		cfg.UserAgentSuffix = data.UserAgentSuffix.ValueString()
+	} else if v, ok := lookupEnv("NETBIRD_USER_AGENT_SUFFIX"); ok {
+		cfg.UserAgentSuffix = v
+	}
+
+	return cfg, ret
+}
+
+// validateManagementURL checks that the management URL is an absolute HTTP(S) URL.
+func validateManagementURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must start with http:// or https://", s)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", s)
+	}
+	return nil
+}
+
+// ValidateConfig checks explicitly configured values, values from environment variables are checked in Configure.
+func (p *NetBirdProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
 	var data NetBirdProviderModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -72,34 +483,196 @@ func (p *NetBirdProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	managementURL := "https://api.netbird.io"
 	if !data.ManagementURL.IsUnknown() && !data.ManagementURL.IsNull() {
-		managementURL = data.ManagementURL.ValueString()
-	} else if v, ok := os.LookupEnv("NB_MANAGEMENT_URL"); ok {
-		managementURL = v
-	}
-	token := data.Token.ValueString()
-	if data.Token.IsNull() || data.Token.IsUnknown() {
-		if v, ok := os.LookupEnv("NB_PAT"); ok {
-			token = v
-		} else {
-			resp.Diagnostics.AddError("Missing required argument", `The argument "token" is required, but was not set.`)
+		if err := validateManagementURL(data.ManagementURL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("management_url"), "Invalid Management URL", formatAPIError(err))
+		}
+	}
+
+	if !data.Token.IsUnknown() && !data.Token.IsNull() && data.Token.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "Invalid Token", `The argument "token" must not be empty, remove it to use the NETBIRD_TOKEN or NB_PAT environment variables instead.`)
+	}
+}
+
+// newHTTPClient returns an HTTP client trusting caCert in addition to the system CAs.
+func newHTTPClient(caCert string) (*http.Client, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, fmt.Errorf("no valid PEM certificates found")
+	}
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected default transport type %T", http.DefaultTransport)
+	}
+	transport := defaultTransport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	return &http.Client{Transport: transport}, nil
+}
+
+// newRateLimitedHTTPClient returns a copy of client sending at most rps requests per second.
+func newRateLimitedHTTPClient(client *http.Client, rps float64) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	limited := *client
+	limited.Transport = &rateLimitedTransport{
+		base:    base,
+		limiter: rate.NewLimiter(rate.Limit(rps), 1),
+	}
+	return &limited
+}
+
+// newConcurrencyLimitedHTTPClient returns a copy of client with at most limit requests in flight.
+func newConcurrencyLimitedHTTPClient(client *http.Client, limit int) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	limited := *client
+	limited.Transport = &concurrencyLimitedTransport{
+		base: base,
+		sem:  make(chan struct{}, limit),
+	}
+	return &limited
+}
+
+// newNotFoundRetryHTTPClient returns a copy of client retrying not found reads of objects it created,
+// backing off from interval up to maxInterval between retries.
+func newNotFoundRetryHTTPClient(client *http.Client, retries int, interval, maxInterval time.Duration) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	retrying := *client
+	retrying.Transport = &notFoundRetryTransport{
+		base:        base,
+		retries:     retries,
+		interval:    interval,
+		maxInterval: maxInterval,
+		created:     map[string]struct{}{},
+	}
+	return &retrying
+}
+
+// newBasePathHTTPClient returns a copy of client sending requests for paths under from to basePath instead.
+func newBasePathHTTPClient(client *http.Client, from, basePath string) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rebased := *client
+	rebased.Transport = &basePathTransport{
+		base:     base,
+		from:     from,
+		basePath: basePath,
+	}
+	return &rebased
+}
+
+// providerUserAgent returns the User-Agent identifying the provider and Terraform versions, followed by suffix if set.
+func providerUserAgent(version, terraformVersion, suffix string) string {
+	userAgent := fmt.Sprintf("terraform-provider-netbird/%s Terraform/%s", version, terraformVersion)
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// validateTenantAccount checks that the impersonated account is accessible with the configured token.
+// It costs one request per provider configuration, only made when tenant_account is set, and fails early
+// instead of every resource reporting a not found or permission error against the wrong account.
+func validateTenantAccount(ctx context.Context, client *netbird.Client, account string) diag.Diagnostics {
+	var ret diag.Diagnostics
+	accounts, err := client.Accounts.List(ctx)
+	if err != nil {
+		ret.AddAttributeError(path.Root("tenant_account"), "Error listing Accounts", formatAPIError(err))
+		return ret
+	}
+	for _, a := range accounts {
+		if a.Id == account {
+			return ret
 		}
 	}
+	ret.AddAttributeError(path.Root("tenant_account"), "Tenant Account Not Found", fmt.Sprintf("Account %q is not accessible with the configured token", account))
+	return ret
+}
+
+func (p *NetBirdProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data NetBirdProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	cfg, d := resolveProviderConfig(data)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setupKeyNameTemplate, err := parseSetupKeyNameTemplate(cfg.SetupKeyNameTemplate)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("setup_key_name_template"), "Invalid Setup Key Name Template", err.Error())
+		return
+	}
+
+	httpClient := http.DefaultClient
+	if cfg.CACert != "" {
+		var err error
+		httpClient, err = newHTTPClient(cfg.CACert)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ca_cert"), "Invalid CA Certificate", formatAPIError(err))
+			return
+		}
+	}
+	if cfg.APIBasePath != defaultAPIBasePath {
+		managementURL, err := url.Parse(cfg.ManagementURL)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("management_url"), "Invalid Management URL", formatAPIError(err))
+			return
+		}
+		// The client always appends the default base path to the management URL
+		from := strings.TrimSuffix(managementURL.Path, "/") + defaultAPIBasePath
+		httpClient = newBasePathHTTPClient(httpClient, from, strings.TrimSuffix(managementURL.Path, "/")+cfg.APIBasePath)
+	}
+	if cfg.MaxConcurrentRequests > 0 {
+		httpClient = newConcurrencyLimitedHTTPClient(httpClient, cfg.MaxConcurrentRequests)
+	}
+	if cfg.RequestsPerSecond > 0 {
+		httpClient = newRateLimitedHTTPClient(httpClient, cfg.RequestsPerSecond)
+	}
+	if cfg.NotFoundRetries > 0 {
+		httpClient = newNotFoundRetryHTTPClient(httpClient, cfg.NotFoundRetries, notFoundRetryInterval, notFoundRetryMaxInterval)
+	}
 	client := netbird.NewWithOptions(
-		netbird.WithManagementURL(managementURL),
-		netbird.WithPAT(token),
-		netbird.WithUserAgent(fmt.Sprintf("terraform-provider-netbird/%s Terraform/%s", p.version, req.TerraformVersion)))
-	if !data.TenantAccount.IsNull() && !data.TenantAccount.IsUnknown() {
-		client = client.Impersonate(data.TenantAccount.ValueString())
-	} else if v, ok := os.LookupEnv("NB_ACCOUNT"); ok {
-		client = client.Impersonate(v)
+		netbird.WithManagementURL(cfg.ManagementURL),
+		netbird.WithPAT(cfg.Token),
+		netbird.WithHttpClient(httpClient),
+		netbird.WithUserAgent(providerUserAgent(p.version, req.TerraformVersion, cfg.UserAgentSuffix)))
+	if cfg.TenantAccount != "" {
+		client = client.Impersonate(cfg.TenantAccount)
+		resp.Diagnostics.Append(validateTenantAccount(ctx, client, cfg.TenantAccount)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resourceData := &providerData{
+		client:               client,
+		deployment:           cfg.deployment(),
+		defaultAutoGroups:    cfg.DefaultAutoGroups,
+		setupKeyNameTemplate: setupKeyNameTemplate,
 	}
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = resourceData
+	resp.ResourceData = resourceData
 }
 
 func (p *NetBirdProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -109,6 +682,7 @@ func (p *NetBirdProvider) Resources(ctx context.Context) []func() resource.Resou
 		NewDNSZone,
 		NewDNSRecord,
 		NewGroup,
+		NewGroupEnsure,
 		NewIdentityProvider,
 		NewNameserverGroup,
 		NewNetwork,
@@ -137,14 +711,20 @@ func (p *NetBirdProvider) DataSources(ctx context.Context) []func() datasource.D
 		NewDNSSettingsDataSource,
 		NewDNSZoneDataSource,
 		NewDNSRecordDataSource,
+		NewEventsDataSource,
+		NewGeoLocationsDataSource,
 		NewGroupDataSource,
+		NewGroupMembershipsDataSource,
 		NewIdentityProviderDataSource,
 		NewNameserverGroupDataSource,
 		NewNetworkDataSource,
 		NewNetworkResourceDataSource,
 		NewNetworkRouterDataSource,
+		NewNetworkRoutersDataSource,
 		NewPeerDataSource,
+		NewPeerDNSConflictsDataSource,
 		NewPeersDataSource,
+		NewPeersSummaryDataSource,
 		NewPolicyDataSource,
 		NewPostureCheckDataSource,
 		NewRouteDataSource,
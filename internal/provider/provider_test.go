@@ -4,6 +4,8 @@ package provider
 
 import (
 	"context"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,15 +14,22 @@ import (
 	"path"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
 )
 
 const apiToken = "nbp_apTmlmUXHSC4PKmHwtIZNaGr8eqcVI2gMURp"
@@ -30,6 +39,14 @@ func valPtr[T any](s T) *T {
 	return &s
 }
 
+func toStringValues(s []string) []attr.Value {
+	ret := make([]attr.Value, len(s))
+	for i, v := range s {
+		ret[i] = types.StringValue(v)
+	}
+	return ret
+}
+
 // GetProjectDir will return the directory where the project is.
 func GetProjectDir() (string, error) {
 	wd, err := os.Getwd()
@@ -91,6 +108,55 @@ func testClient() *netbird.Client {
 	return netbird.New(managementURL, apiToken)
 }
 
+// testStubClient starts a stub management server serving handler and returns a
+// client pointed at it, the server is closed when the test finishes.
+func testStubClient(t *testing.T, handler http.HandlerFunc) *netbird.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return netbird.New(server.URL, apiToken)
+}
+
+// testResourceState builds Terraform state for resource r from a data model.
+func testResourceState(t *testing.T, r resource.Resource, data any) tfsdk.State {
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+		Schema: schemaResp.Schema,
+	}
+	diags := state.Set(context.Background(), data)
+	if diags.HasError() {
+		t.Fatalf("Failed to build state: %v", diags.Errors())
+	}
+	return state
+}
+
+// testDataSourceRead builds a read request for data source d with data as its
+// configuration, alongside a response with empty state.
+func testDataSourceRead(t *testing.T, d datasource.DataSource, data any) (datasource.ReadRequest, *datasource.ReadResponse) {
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	tfType := schemaResp.Schema.Type().TerraformType(context.Background())
+
+	// tfsdk.Config can't be set from a model, build it through tfsdk.State instead
+	config := tfsdk.State{
+		Raw:    tftypes.NewValue(tfType, nil),
+		Schema: schemaResp.Schema,
+	}
+	diags := config.Set(context.Background(), data)
+	if diags.HasError() {
+		t.Fatalf("Failed to build config: %v", diags.Errors())
+	}
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Raw: config.Raw, Schema: schemaResp.Schema},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Raw: tftypes.NewValue(tfType, nil), Schema: schemaResp.Schema},
+	}
+	return req, resp
+}
+
 func matchPairs(pairs map[string][]any) error {
 	for name, p := range pairs {
 		if reflect.ValueOf(p[1]).Kind() == reflect.Pointer {
@@ -117,18 +183,390 @@ func matchPairs(pairs map[string][]any) error {
 	return nil
 }
 
+// testProviderConfig builds provider configuration from values, attributes not in values are null.
+func testProviderConfig(p provider.Provider, values map[string]tftypes.Value) tfsdk.Config {
+	schemaResp := provider.SchemaResponse{}
+	p.Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
+
+	configType, _ := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attrs := map[string]tftypes.Value{}
+	for name, attrType := range configType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return tfsdk.Config{
+		Raw:    tftypes.NewValue(configType, attrs),
+		Schema: schemaResp.Schema,
+	}
+}
+
+func Test_resolveProviderConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		data     NetBirdProviderModel
+		expected providerConfig
+		errors   int
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"NETBIRD_TOKEN": "envtoken"},
+			expected: providerConfig{
+				ManagementURL:   defaultManagementURL,
+				APIBasePath:     defaultAPIBasePath,
+				Token:           "envtoken",
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+		},
+		{
+			name: "netbird env",
+			env: map[string]string{
+				"NETBIRD_MANAGEMENT_URL": "https://netbird.example.com",
+				"NETBIRD_TOKEN":          "envtoken",
+				"NETBIRD_CA_CERT":        "envcert",
+			},
+			expected: providerConfig{
+				ManagementURL:   "https://netbird.example.com",
+				APIBasePath:     defaultAPIBasePath,
+				Token:           "envtoken",
+				CACert:          "envcert",
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+		},
+		{
+			name: "legacy env",
+			env: map[string]string{
+				"NB_MANAGEMENT_URL": "https://nb.example.com",
+				"NB_PAT":            "pat",
+				"NB_ACCOUNT":        "acc1",
+			},
+			expected: providerConfig{
+				ManagementURL:   "https://nb.example.com",
+				APIBasePath:     defaultAPIBasePath,
+				Token:           "pat",
+				TenantAccount:   "acc1",
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+		},
+		{
+			name: "netbird env over legacy env",
+			env: map[string]string{
+				"NETBIRD_MANAGEMENT_URL": "https://netbird.example.com",
+				"NB_MANAGEMENT_URL":      "https://nb.example.com",
+				"NETBIRD_TOKEN":          "envtoken",
+				"NB_PAT":                 "pat",
+			},
+			expected: providerConfig{
+				ManagementURL:   "https://netbird.example.com",
+				APIBasePath:     defaultAPIBasePath,
+				Token:           "envtoken",
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+		},
+		{
+			name: "config over env",
+			env: map[string]string{
+				"NETBIRD_MANAGEMENT_URL": "https://netbird.example.com",
+				"NETBIRD_TOKEN":          "envtoken",
+				"NETBIRD_CA_CERT":        "envcert",
+			},
+			data: NetBirdProviderModel{
+				ManagementURL: types.StringValue("https://config.example.com"),
+				Token:         types.StringValue("configtoken"),
+				CACert:        types.StringValue("configcert"),
+			},
+			expected: providerConfig{
+				ManagementURL:   "https://config.example.com",
+				APIBasePath:     defaultAPIBasePath,
+				Token:           "configtoken",
+				CACert:          "configcert",
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+		},
+		{
+			name: "requests per second",
+			env:  map[string]string{"NETBIRD_TOKEN": "envtoken"},
+			data: NetBirdProviderModel{
+				RequestsPerSecond: types.Float64Value(2.5),
+			},
+			expected: providerConfig{
+				ManagementURL:     defaultManagementURL,
+				APIBasePath:       defaultAPIBasePath,
+				Token:             "envtoken",
+				RequestsPerSecond: 2.5,
+				NotFoundRetries:   defaultNotFoundRetries,
+			},
+		},
+		{
+			name: "not found retries disabled",
+			env:  map[string]string{"NETBIRD_TOKEN": "envtoken"},
+			data: NetBirdProviderModel{
+				NotFoundRetries: types.Int32Value(0),
+			},
+			expected: providerConfig{
+				ManagementURL: defaultManagementURL,
+				APIBasePath:   defaultAPIBasePath,
+				Token:         "envtoken",
+			},
+		},
+		{
+			name: "api base path",
+			env:  map[string]string{"NETBIRD_TOKEN": "envtoken"},
+			data: NetBirdProviderModel{
+				APIBasePath: types.StringValue("/custom/api/"),
+			},
+			expected: providerConfig{
+				ManagementURL:   defaultManagementURL,
+				APIBasePath:     "/custom/api",
+				Token:           "envtoken",
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+		},
+		{
+			name: "default auto groups",
+			env:  map[string]string{"NETBIRD_TOKEN": "envtoken"},
+			data: NetBirdProviderModel{
+				DefaultAutoGroups: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1"), types.StringValue("g2")}),
+			},
+			expected: providerConfig{
+				ManagementURL:     defaultManagementURL,
+				APIBasePath:       defaultAPIBasePath,
+				Token:             "envtoken",
+				NotFoundRetries:   defaultNotFoundRetries,
+				DefaultAutoGroups: []string{"g1", "g2"},
+			},
+		},
+		{
+			name: "missing token",
+			expected: providerConfig{
+				ManagementURL:   defaultManagementURL,
+				APIBasePath:     defaultAPIBasePath,
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+			errors: 1,
+		},
+		{
+			name: "empty token",
+			env:  map[string]string{"NETBIRD_TOKEN": "envtoken"},
+			data: NetBirdProviderModel{
+				Token: types.StringValue(""),
+			},
+			expected: providerConfig{
+				ManagementURL:   defaultManagementURL,
+				APIBasePath:     defaultAPIBasePath,
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+			errors: 1,
+		},
+		{
+			name: "malformed management url",
+			env: map[string]string{
+				"NETBIRD_MANAGEMENT_URL": "netbird.example.com",
+				"NETBIRD_TOKEN":          "envtoken",
+			},
+			expected: providerConfig{
+				ManagementURL:   "netbird.example.com",
+				APIBasePath:     defaultAPIBasePath,
+				Token:           "envtoken",
+				NotFoundRetries: defaultNotFoundRetries,
+			},
+			errors: 1,
+		},
+	}
+
+	envKeys := []string{"NETBIRD_MANAGEMENT_URL", "NB_MANAGEMENT_URL", "NETBIRD_TOKEN", "NB_PAT", "NETBIRD_CA_CERT", "NB_ACCOUNT"}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, k := range envKeys {
+				t.Setenv(k, "")
+				_ = os.Unsetenv(k)
+			}
+			for k, v := range c.env {
+				t.Setenv(k, v)
+			}
+
+			out, outDiag := resolveProviderConfig(c.data)
+			if outDiag.ErrorsCount() != c.errors {
+				t.Fatalf("Expected %d error diagnostics, found %d", c.errors, outDiag.ErrorsCount())
+			}
+			if !reflect.DeepEqual(out, c.expected) {
+				t.Fatalf("Expected:\n%#v\nFound:\n%#v", c.expected, out)
+			}
+		})
+	}
+}
+
+func Test_providerConfigDeployment(t *testing.T) {
+	cases := []struct {
+		cfg      providerConfig
+		expected string
+	}{
+		{cfg: providerConfig{ManagementURL: defaultManagementURL}, expected: deploymentCloud},
+		{cfg: providerConfig{ManagementURL: "https://api.netbird.io/"}, expected: deploymentCloud},
+		{cfg: providerConfig{ManagementURL: "https://netbird.example.com"}, expected: deploymentSelfHosted},
+		{cfg: providerConfig{ManagementURL: "https://netbird.example.com", Deployment: deploymentCloud}, expected: deploymentCloud},
+		{cfg: providerConfig{ManagementURL: defaultManagementURL, Deployment: deploymentSelfHosted}, expected: deploymentSelfHosted},
+	}
+
+	for _, c := range cases {
+		if out := c.cfg.deployment(); out != c.expected {
+			t.Fatalf("Expected deployment %s for %#v, found %s", c.expected, c.cfg, out)
+		}
+	}
+}
+
+func TestProviderValidateConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   map[string]tftypes.Value
+		expected []string
+	}{
+		{
+			name: "valid",
+			values: map[string]tftypes.Value{
+				"management_url": tftypes.NewValue(tftypes.String, "https://netbird.example.com"),
+				"token":          tftypes.NewValue(tftypes.String, "token"),
+			},
+		},
+		{
+			name: "unset",
+		},
+		{
+			name:     "missing token",
+			values:   map[string]tftypes.Value{"token": tftypes.NewValue(tftypes.String, "")},
+			expected: []string{"token"},
+		},
+		{
+			name:     "missing scheme",
+			values:   map[string]tftypes.Value{"management_url": tftypes.NewValue(tftypes.String, "netbird.example.com")},
+			expected: []string{"management_url"},
+		},
+		{
+			name:     "malformed url",
+			values:   map[string]tftypes.Value{"management_url": tftypes.NewValue(tftypes.String, "https://netbird example.com:port")},
+			expected: []string{"management_url"},
+		},
+		{
+			name:     "missing host",
+			values:   map[string]tftypes.Value{"management_url": tftypes.NewValue(tftypes.String, "https:///api")},
+			expected: []string{"management_url"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &NetBirdProvider{version: "test"}
+			resp := provider.ValidateConfigResponse{}
+			p.ValidateConfig(context.Background(), provider.ValidateConfigRequest{Config: testProviderConfig(p, c.values)}, &resp)
+			errors := resp.Diagnostics.Errors()
+			if len(errors) != len(c.expected) {
+				t.Fatalf("Expected %d error diagnostics, found %v", len(c.expected), errors)
+			}
+			for i, e := range errors {
+				withPath, ok := e.(diag.DiagnosticWithPath)
+				if !ok || withPath.Path().String() != c.expected[i] {
+					t.Fatalf("Expected error for %s, found %v", c.expected[i], e)
+				}
+			}
+		})
+	}
+}
+
 // TestProviderUserAgent verifies that the provider sends the correct User-Agent header.
 func TestProviderUserAgent(t *testing.T) {
-	var capturedUserAgent string
 	expectedVersion := "1.2.3"
 	expectedTerraformVersion := "1.5.0"
-	expectedUserAgent := fmt.Sprintf("terraform-provider-netbird/%s Terraform/%s", expectedVersion, expectedTerraformVersion)
+	cases := []struct {
+		name              string
+		suffix            tftypes.Value
+		envSuffix         string
+		expectedUserAgent string
+	}{
+		{
+			name:              "default",
+			suffix:            tftypes.NewValue(tftypes.String, nil),
+			expectedUserAgent: fmt.Sprintf("terraform-provider-netbird/%s Terraform/%s", expectedVersion, expectedTerraformVersion),
+		},
+		{
+			name:              "suffix",
+			suffix:            tftypes.NewValue(tftypes.String, "ci-pipeline/42"),
+			envSuffix:         "from-env",
+			expectedUserAgent: fmt.Sprintf("terraform-provider-netbird/%s Terraform/%s ci-pipeline/42", expectedVersion, expectedTerraformVersion),
+		},
+		{
+			name:              "suffix from environment",
+			suffix:            tftypes.NewValue(tftypes.String, nil),
+			envSuffix:         "from-env",
+			expectedUserAgent: fmt.Sprintf("terraform-provider-netbird/%s Terraform/%s from-env", expectedVersion, expectedTerraformVersion),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var capturedUserAgent string
+
+			// Create a test HTTP server that captures the User-Agent header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedUserAgent = r.Header.Get("User-Agent")
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer server.Close()
+
+			t.Setenv("NB_MANAGEMENT_URL", server.URL)
+			t.Setenv("NB_PAT", "test-token")
+			if c.envSuffix != "" {
+				t.Setenv("NETBIRD_USER_AGENT_SUFFIX", c.envSuffix)
+			}
+
+			p, ok := New(expectedVersion)().(*NetBirdProvider)
+			if !ok {
+				t.Fatal("failed to cast to *NetBirdProvider")
+			}
+
+			req := provider.ConfigureRequest{
+				TerraformVersion: expectedTerraformVersion,
+				Config: testProviderConfig(p, map[string]tftypes.Value{
+					"user_agent_suffix": c.suffix,
+				}),
+			}
+
+			resp := provider.ConfigureResponse{}
+			p.Configure(context.Background(), req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Failed to configure provider: %v", resp.Diagnostics.Errors())
+			}
+
+			data, ok := resp.ResourceData.(*providerData)
+			if !ok {
+				t.Fatal("Failed to get client from provider response")
+			}
+			client := data.client
+
+			_, err := client.Accounts.List(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+
+			if capturedUserAgent != c.expectedUserAgent {
+				t.Errorf("User-Agent mismatch:\nExpected: %s\nGot:      %s", c.expectedUserAgent, capturedUserAgent)
+			}
+		})
+	}
+}
 
-	// Create a test HTTP server that captures the User-Agent header
+// TestProviderRateLimit verifies that requests_per_second delays requests instead of failing them.
+func TestProviderRateLimit(t *testing.T) {
+	requests := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		capturedUserAgent = r.Header.Get("User-Agent")
+		requests++
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("[]"))
 	}))
 	defer server.Close()
@@ -136,47 +574,557 @@ func TestProviderUserAgent(t *testing.T) {
 	t.Setenv("NB_MANAGEMENT_URL", server.URL)
 	t.Setenv("NB_PAT", "test-token")
 
-	p, ok := New(expectedVersion)().(*NetBirdProvider)
+	p := New("test")()
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(p, map[string]tftypes.Value{
+			"requests_per_second": tftypes.NewValue(tftypes.Number, 10),
+		}),
+	}
+	resp := provider.ConfigureResponse{}
+	p.Configure(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Failed to configure provider: %v", resp.Diagnostics.Errors())
+	}
+
+	data, ok := resp.ResourceData.(*providerData)
 	if !ok {
-		t.Fatal("failed to cast to *NetBirdProvider")
+		t.Fatal("Failed to get client from provider response")
 	}
+	client := data.client
 
-	schemaReq := provider.SchemaRequest{}
-	schemaResp := provider.SchemaResponse{}
-	p.Schema(context.Background(), schemaReq, &schemaResp)
+	// The first request is sent immediately, every following one waits 100ms
+	calls := 5
+	start := time.Now()
+	for range calls {
+		if _, err := client.Peers.List(context.Background()); err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+	}
+	elapsed := time.Since(start)
 
-	configValue := schemaResp.Schema.Type().TerraformType(context.Background())
+	if requests != calls {
+		t.Fatalf("Expected %d requests, found %d", calls, requests)
+	}
+	if minElapsed := time.Duration(calls-1) * 100 * time.Millisecond; elapsed < minElapsed {
+		t.Fatalf("Expected %d requests to take at least %s, took %s", calls, minElapsed, elapsed)
+	}
+}
 
+// TestProviderMaxConcurrentRequests verifies that max_concurrent_requests caps the requests in flight.
+func TestProviderMaxConcurrentRequests(t *testing.T) {
+	limit := 2
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	t.Setenv("NB_MANAGEMENT_URL", server.URL)
+	t.Setenv("NB_PAT", "test-token")
+
+	p := New("test")()
 	req := provider.ConfigureRequest{
-		TerraformVersion: expectedTerraformVersion,
-	}
-	req.Config = tfsdk.Config{
-		Raw: tftypes.NewValue(configValue, map[string]tftypes.Value{
-			"management_url": tftypes.NewValue(tftypes.String, nil),
-			"token":          tftypes.NewValue(tftypes.String, nil),
-			"tenant_account": tftypes.NewValue(tftypes.String, nil),
+		Config: testProviderConfig(p, map[string]tftypes.Value{
+			"max_concurrent_requests": tftypes.NewValue(tftypes.Number, limit),
 		}),
-		Schema: schemaResp.Schema,
 	}
-
 	resp := provider.ConfigureResponse{}
 	p.Configure(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Failed to configure provider: %v", resp.Diagnostics.Errors())
+	}
 
+	data, ok := resp.ResourceData.(*providerData)
+	if !ok {
+		t.Fatal("Failed to get client from provider response")
+	}
+	client := data.client
+
+	calls := 8
+	var wg sync.WaitGroup
+	errs := make(chan error, calls)
+	for range calls {
+		wg.Go(func() {
+			_, err := client.Peers.List(context.Background())
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+	}
+
+	if maxInFlight > limit {
+		t.Fatalf("Expected at most %d concurrent requests, found %d", limit, maxInFlight)
+	}
+}
+
+// TestProviderMaxConcurrentRequests_canceled verifies that requests waiting for a slot stop when their context is canceled.
+func TestProviderMaxConcurrentRequests_errorResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"group not found","code":404}`))
+	}))
+	defer server.Close()
+
+	// The netbird client never closes the body of error responses, they must not keep their slot
+	client := netbird.NewWithOptions(
+		netbird.WithManagementURL(server.URL),
+		netbird.WithPAT("test-token"),
+		netbird.WithHttpClient(newConcurrencyLimitedHTTPClient(http.DefaultClient, 2)))
+	for i := range 5 {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		_, err := client.Groups.Get(ctx, "g1")
+		cancel()
+		if !isNotFound(err) {
+			t.Fatalf("Expected request %d to fail with not found, found %v", i+1, err)
+		}
+	}
+}
+
+func TestProviderMaxConcurrentRequests_canceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newConcurrencyLimitedHTTPClient(http.DefaultClient, 1)
+	go func() {
+		if resp, err := client.Get(server.URL); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected waiting request to fail with %v, found %v", context.DeadlineExceeded, err)
+	}
+}
+
+// TestProviderAPIBasePath verifies that api_base_path replaces the default API base path in request URLs.
+func TestProviderAPIBasePath(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !strings.HasPrefix(r.URL.Path, "/custom/api/") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found","code":404}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	t.Setenv("NB_MANAGEMENT_URL", server.URL)
+	t.Setenv("NB_PAT", "test-token")
+
+	p := New("test")()
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(p, map[string]tftypes.Value{
+			"api_base_path": tftypes.NewValue(tftypes.String, "/custom/api"),
+		}),
+	}
+	resp := provider.ConfigureResponse{}
+	p.Configure(context.Background(), req, &resp)
 	if resp.Diagnostics.HasError() {
 		t.Fatalf("Failed to configure provider: %v", resp.Diagnostics.Errors())
 	}
 
-	client, ok := resp.ResourceData.(*netbird.Client)
+	data, ok := resp.ResourceData.(*providerData)
 	if !ok {
 		t.Fatal("Failed to get client from provider response")
 	}
+	client := data.client
 
-	_, err := client.Accounts.List(context.Background())
-	if err != nil {
+	if _, err := client.Peers.List(context.Background()); err != nil {
 		t.Fatalf("Failed to make request: %v", err)
 	}
+	if len(paths) != 1 || paths[0] != "/custom/api/peers" {
+		t.Fatalf("Expected a single request to /custom/api/peers, found %v", paths)
+	}
+}
 
-	if capturedUserAgent != expectedUserAgent {
-		t.Errorf("User-Agent mismatch:\nExpected: %s\nGot:      %s", expectedUserAgent, capturedUserAgent)
+func TestProviderSetupKeyNameTemplate(t *testing.T) {
+	t.Setenv("NB_MANAGEMENT_URL", "https://example.com")
+	t.Setenv("NB_PAT", "test-token")
+
+	cases := []struct {
+		template string
+		valid    bool
+	}{
+		{template: "env-{{.Name}}", valid: true},
+		{template: "env-{{.Name", valid: false},
+		{template: "env-{{.Environment}}", valid: false},
+	}
+
+	for _, c := range cases {
+		p := New("test")()
+		req := provider.ConfigureRequest{
+			Config: testProviderConfig(p, map[string]tftypes.Value{
+				"setup_key_name_template": tftypes.NewValue(tftypes.String, c.template),
+			}),
+		}
+		resp := provider.ConfigureResponse{}
+		p.Configure(context.Background(), req, &resp)
+		if c.valid && resp.Diagnostics.HasError() {
+			t.Fatalf("Expected template %q to be valid, found %v", c.template, resp.Diagnostics.Errors())
+		}
+		if !c.valid && (resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid Setup Key Name Template") {
+			t.Fatalf("Expected Invalid Setup Key Name Template error for %q, found %v", c.template, resp.Diagnostics.Errors())
+		}
+		if !c.valid {
+			continue
+		}
+
+		r := NewSetupKey().(*SetupKey)
+		configureResp := resource.ConfigureResponse{}
+		r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: resp.ResourceData}, &configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("Failed to configure setup key resource: %v", configureResp.Diagnostics.Errors())
+		}
+		if r.nameTemplate == nil || r.nameTemplate.Root.String() != c.template {
+			t.Fatalf("Expected setup key resource to use template %q, found %v", c.template, r.nameTemplate)
+		}
+	}
+}
+
+func TestProviderCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	t.Cleanup(server.Close)
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	cases := []struct {
+		caCert        string
+		expectedError string
+	}{
+		{caCert: caCert},
+		{caCert: "not a certificate", expectedError: "Invalid CA Certificate"},
+	}
+
+	for _, c := range cases {
+		t.Setenv("NB_MANAGEMENT_URL", server.URL)
+		t.Setenv("NB_PAT", "test-token")
+
+		p := New("test")()
+		req := provider.ConfigureRequest{
+			Config: testProviderConfig(p, map[string]tftypes.Value{
+				"ca_cert": tftypes.NewValue(tftypes.String, c.caCert),
+			}),
+		}
+		resp := provider.ConfigureResponse{}
+		p.Configure(context.Background(), req, &resp)
+		if c.expectedError != "" {
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Failed to configure provider: %v", resp.Diagnostics.Errors())
+		}
+
+		data, ok := resp.ResourceData.(*providerData)
+		if !ok {
+			t.Fatal("Failed to get client from provider response")
+		}
+		// The test server certificate is self-signed, requests only succeed when ca_cert is trusted
+		if _, err := data.client.Peers.List(context.Background()); err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+	}
+}
+
+func TestProviderTenantAccount(t *testing.T) {
+	cases := []struct {
+		name          string
+		account       string
+		expectedError string
+	}{
+		{name: "accessible account", account: "account2"},
+		{name: "inaccessible account", account: "account3", expectedError: "Tenant Account Not Found"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var accounts []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accounts = append(accounts, r.URL.Query().Get("account"))
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/api/accounts" {
+					_, _ = w.Write([]byte(`[{"id":"account2"}]`))
+					return
+				}
+				_, _ = w.Write([]byte("[]"))
+			}))
+			t.Cleanup(server.Close)
+
+			t.Setenv("NB_MANAGEMENT_URL", server.URL)
+			t.Setenv("NB_PAT", "test-token")
+
+			p := New("test")()
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(p, map[string]tftypes.Value{
+					"tenant_account": tftypes.NewValue(tftypes.String, c.account),
+				}),
+			}
+			resp := provider.ConfigureResponse{}
+			p.Configure(context.Background(), req, &resp)
+			if c.expectedError != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+					t.Fatalf("Expected %s error, found %v", c.expectedError, resp.Diagnostics.Errors())
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Failed to configure provider: %v", resp.Diagnostics.Errors())
+			}
+
+			data, ok := resp.ResourceData.(*providerData)
+			if !ok {
+				t.Fatal("Failed to get client from provider response")
+			}
+			client := data.client
+			if _, err := client.Peers.List(context.Background()); err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			if len(accounts) != 2 || accounts[0] != c.account || accounts[1] != c.account {
+				t.Fatalf("Expected all requests to target account %s, found %v", c.account, accounts)
+			}
+		})
+	}
+}
+
+func TestProviderClientType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	t.Setenv("NB_MANAGEMENT_URL", server.URL)
+	t.Setenv("NB_PAT", "test-token")
+
+	p := New("test")()
+	req := provider.ConfigureRequest{Config: testProviderConfig(p, map[string]tftypes.Value{})}
+	resp := provider.ConfigureResponse{}
+	p.Configure(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Failed to configure provider: %v", resp.Diagnostics.Errors())
+	}
+
+	// Resources and data sources assert the provider data type, a mismatch compiles but fails every Configure call
+	for _, newResource := range p.Resources(context.Background()) {
+		r, ok := newResource().(resource.ResourceWithConfigure)
+		if !ok {
+			continue
+		}
+		configureResp := resource.ConfigureResponse{}
+		r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: resp.ResourceData}, &configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("Expected %T to accept the provider client, found %v", r, configureResp.Diagnostics.Errors())
+		}
+	}
+	for _, newDataSource := range p.DataSources(context.Background()) {
+		d, ok := newDataSource().(datasource.DataSourceWithConfigure)
+		if !ok {
+			continue
+		}
+		configureResp := datasource.ConfigureResponse{}
+		d.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: resp.DataSourceData}, &configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("Expected %T to accept the provider client, found %v", d, configureResp.Diagnostics.Errors())
+		}
+	}
+}
+
+func TestProviderResourcesDelete_notFound(t *testing.T) {
+	deletes := 0
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"object not found","code":404}`))
+	})
+
+	// Identifiers are set and deletion is forced so every resource calling the API on delete does so
+	stateValues := map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "id1"),
+		"network_id":             tftypes.NewValue(tftypes.String, "network1"),
+		"zone_id":                tftypes.NewValue(tftypes.String, "zone1"),
+		"user_id":                tftypes.NewValue(tftypes.String, "user1"),
+		"created":                tftypes.NewValue(tftypes.Bool, true),
+		"delete_peer_on_destroy": tftypes.NewValue(tftypes.Bool, true),
+	}
+
+	p := New("test")()
+	for _, newResource := range p.Resources(context.Background()) {
+		r := newResource()
+		metadataResp := resource.MetadataResponse{}
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "netbird"}, &metadataResp)
+		if rc, ok := r.(resource.ResourceWithConfigure); ok {
+			rc.Configure(context.Background(), resource.ConfigureRequest{ProviderData: &providerData{client: client}}, &resource.ConfigureResponse{})
+		}
+
+		schemaResp := resource.SchemaResponse{}
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+		stateType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+		attrs := map[string]tftypes.Value{}
+		for name, attrType := range stateType.AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+			if v, ok := stateValues[name]; ok && v.Type().Equal(attrType) {
+				attrs[name] = v
+			}
+		}
+
+		resp := resource.DeleteResponse{}
+		r.Delete(context.Background(), resource.DeleteRequest{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, attrs)},
+		}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected %s to treat a not found delete as success, found %v", metadataResp.TypeName, resp.Diagnostics.Errors())
+		}
+	}
+	if deletes == 0 {
+		t.Fatal("Expected resources to send delete requests")
+	}
+}
+
+func TestNotFoundRetry(t *testing.T) {
+	cases := []struct {
+		name             string
+		create           bool
+		notFoundReads    int
+		expectedErr      bool
+		expectedRequests int
+	}{
+		{
+			name:             "created object found after retry",
+			create:           true,
+			notFoundReads:    1,
+			expectedRequests: 2,
+		},
+		{
+			name:             "created object not found after all retries",
+			create:           true,
+			notFoundReads:    10,
+			expectedErr:      true,
+			expectedRequests: 4,
+		},
+		{
+			name:             "unknown object is not retried",
+			notFoundReads:    10,
+			expectedErr:      true,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			reads := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					_, _ = w.Write([]byte(`{"id":"g1","name":"group"}`))
+					return
+				}
+				reads++
+				if reads <= c.notFoundReads {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message":"group not found","code":404}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":"g1","name":"group"}`))
+			}))
+			defer server.Close()
+
+			client := netbird.NewWithOptions(
+				netbird.WithManagementURL(server.URL),
+				netbird.WithPAT("test-token"),
+				netbird.WithHttpClient(newNotFoundRetryHTTPClient(http.DefaultClient, 3, time.Millisecond, 4*time.Millisecond)))
+
+			if c.create {
+				if _, err := client.Groups.Create(context.Background(), api.GroupRequest{Name: "group"}); err != nil {
+					t.Fatalf("Failed to create group: %v", err)
+				}
+			}
+			_, err := client.Groups.Get(context.Background(), "g1")
+			if (err != nil) != c.expectedErr {
+				t.Fatalf("Expected error %t, found %v", c.expectedErr, err)
+			}
+			if reads != c.expectedRequests {
+				t.Fatalf("Expected %d read requests, found %d", c.expectedRequests, reads)
+			}
+		})
+	}
+}
+
+func TestNotFoundRetry_forgetsReadObjects(t *testing.T) {
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"id":"g1","name":"group"}`))
+			return
+		}
+		reads++
+		if reads > 1 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"group not found","code":404}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"g1","name":"group"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	httpClient := newNotFoundRetryHTTPClient(http.DefaultClient, 3, time.Millisecond, 4*time.Millisecond)
+	client := netbird.NewWithOptions(
+		netbird.WithManagementURL(server.URL),
+		netbird.WithPAT("test-token"),
+		netbird.WithHttpClient(httpClient))
+
+	if _, err := client.Groups.Create(context.Background(), api.GroupRequest{Name: "group"}); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	if _, err := client.Groups.Get(context.Background(), "g1"); err != nil {
+		t.Fatalf("Failed to read group: %v", err)
+	}
+	if transport, ok := httpClient.Transport.(*notFoundRetryTransport); !ok || len(transport.created) != 0 {
+		t.Fatalf("Expected read object to be forgotten, found %#v", httpClient.Transport)
+	}
+
+	if _, err := client.Groups.Get(context.Background(), "g1"); err == nil {
+		t.Fatal("Expected not found error, found none")
+	}
+	if reads != 2 {
+		t.Fatalf("Expected 2 read requests, found %d", reads)
 	}
 }
@@ -66,16 +66,16 @@ func (d *ReverseProxyClustersDataSource) Configure(ctx context.Context, req data
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *ReverseProxyClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -88,7 +88,7 @@ func (d *ReverseProxyClustersDataSource) Read(ctx context.Context, req datasourc
 
 	clusters, err := d.client.ReverseProxyClusters.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing reverse proxy clusters", err.Error())
+		resp.Diagnostics.AddError("Error listing reverse proxy clusters", formatAPIError(err))
 		return
 	}
 
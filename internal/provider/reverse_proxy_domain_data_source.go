@@ -72,16 +72,16 @@ func (d *ReverseProxyDomainDataSource) Configure(ctx context.Context, req dataso
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *ReverseProxyDomainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -99,7 +99,7 @@ func (d *ReverseProxyDomainDataSource) Read(ctx context.Context, req datasource.
 
 	domains, err := d.client.ReverseProxyDomains.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing reverse proxy domains", err.Error())
+		resp.Diagnostics.AddError("Error listing reverse proxy domains", formatAPIError(err))
 		return
 	}
 
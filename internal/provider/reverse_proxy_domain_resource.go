@@ -82,16 +82,16 @@ func (r *ReverseProxyDomain) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func reverseProxyDomainAPIToTerraform(domain *api.ReverseProxyDomain, data *ReverseProxyDomainModel) {
@@ -117,7 +117,7 @@ func (r *ReverseProxyDomain) Create(ctx context.Context, req resource.CreateRequ
 
 	domain, err := r.client.ReverseProxyDomains.Create(ctx, domainReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating reverse proxy domain", err.Error())
+		resp.Diagnostics.AddError("Error creating reverse proxy domain", formatAPIError(err))
 		return
 	}
 
@@ -136,7 +136,7 @@ func (r *ReverseProxyDomain) Read(ctx context.Context, req resource.ReadRequest,
 	// The API has no single-get endpoint for domains, so we list and filter.
 	domains, err := r.client.ReverseProxyDomains.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing reverse proxy domains", err.Error())
+		resp.Diagnostics.AddError("Error listing reverse proxy domains", formatAPIError(err))
 		return
 	}
 
@@ -164,10 +164,10 @@ func (r *ReverseProxyDomain) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	if err := r.client.ReverseProxyDomains.Delete(ctx, data.Id.ValueString()); err != nil {
-		if netbird.IsNotFound(err) {
+		if isNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Error deleting reverse proxy domain", err.Error())
+		resp.Diagnostics.AddError("Error deleting reverse proxy domain", formatAPIError(err))
 	}
 }
 
@@ -174,16 +174,16 @@ func (d *ReverseProxyServiceDataSource) Configure(ctx context.Context, req datas
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *ReverseProxyServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -201,7 +201,7 @@ func (d *ReverseProxyServiceDataSource) Read(ctx context.Context, req datasource
 
 	services, err := d.client.ReverseProxyServices.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing reverse proxy services", err.Error())
+		resp.Diagnostics.AddError("Error listing reverse proxy services", formatAPIError(err))
 		return
 	}
 
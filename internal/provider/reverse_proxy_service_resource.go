@@ -308,16 +308,16 @@ func (r *ReverseProxyService) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func reverseProxyServiceAPIToTerraform(ctx context.Context, svc *api.Service, data *ReverseProxyServiceModel) diag.Diagnostics {
@@ -615,7 +615,7 @@ func (r *ReverseProxyService) Create(ctx context.Context, req resource.CreateReq
 
 	svc, err := r.client.ReverseProxyServices.Create(ctx, serviceReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating reverse proxy service", err.Error())
+		resp.Diagnostics.AddError("Error creating reverse proxy service", formatAPIError(err))
 		return
 	}
 
@@ -664,7 +664,7 @@ func (r *ReverseProxyService) Read(ctx context.Context, req resource.ReadRequest
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error getting reverse proxy service", err.Error())
+		resp.Diagnostics.AddError("Error getting reverse proxy service", formatAPIError(err))
 		return
 	}
 
@@ -707,7 +707,7 @@ func (r *ReverseProxyService) Update(ctx context.Context, req resource.UpdateReq
 
 	svc, err := r.client.ReverseProxyServices.Update(ctx, data.Id.ValueString(), serviceReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating reverse proxy service", err.Error())
+		resp.Diagnostics.AddError("Error updating reverse proxy service", formatAPIError(err))
 		return
 	}
 
@@ -747,7 +747,10 @@ func (r *ReverseProxyService) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	if err := r.client.ReverseProxyServices.Delete(ctx, data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting reverse proxy service", err.Error())
+		if isNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting reverse proxy service", formatAPIError(err))
 	}
 }
 
@@ -111,18 +111,18 @@ func (d *RouteDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *RouteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -142,7 +142,7 @@ func (d *RouteDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	routes, err := d.client.Routes.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Routes", err.Error())
+		resp.Diagnostics.AddError("Error listing Routes", formatAPIError(err))
 		return
 	}
 
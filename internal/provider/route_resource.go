@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -30,6 +31,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Route{}
 var _ resource.ResourceWithImportState = &Route{}
+var _ resource.ResourceWithConfigValidators = &Route{}
 
 func NewRoute() resource.Resource {
 	return &Route{}
@@ -59,6 +61,12 @@ type RouteModel struct {
 	SkipAutoApply       types.Bool   `tfsdk:"skip_auto_apply"`
 }
 
+// RouteResourceModel describes the resource data model.
+type RouteResourceModel struct {
+	RouteModel
+	ValidateGroups types.Bool `tfsdk:"validate_groups"`
+}
+
 func (r *Route) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_route"
 }
@@ -79,7 +87,7 @@ func (r *Route) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 				Computed:            true,
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Route description",
+				MarkdownDescription: "Route description, an empty string when not set",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
@@ -107,15 +115,14 @@ func (r *Route) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 				Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("peer"))},
 			},
 			"network": schema.StringAttribute{
-				MarkdownDescription: "Network range in CIDR format, Conflicts with domains",
+				MarkdownDescription: "Network range in CIDR format, exactly one of network and domains must be set",
 				Optional:            true,
-				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("domains"))},
 			},
 			"domains": schema.ListAttribute{
-				MarkdownDescription: "Domain list to be dynamically resolved. Max of 32 domains can be added per route configuration. Conflicts with network",
+				MarkdownDescription: "Domain list to be dynamically resolved. Max of 32 domains can be added per route configuration, exactly one of network and domains must be set",
 				ElementType:         types.StringType,
 				Optional:            true,
-				Validators:          []validator.List{listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("network")), listvalidator.SizeAtMost(32)},
+				Validators:          []validator.List{listvalidator.SizeAtMost(32)},
 			},
 			"metric": schema.Int32Attribute{
 				MarkdownDescription: "Route metric number. Lowest number has higher priority",
@@ -137,7 +144,7 @@ func (r *Route) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 				Validators:          []validator.List{listvalidator.SizeAtLeast(1)},
 			},
 			"keep_route": schema.BoolAttribute{
-				MarkdownDescription: "Indicate if the route should be kept after a domain doesn't resolve that IP anymore",
+				MarkdownDescription: "Indicate if the route should be kept after a domain doesn't resolve that IP anymore, only applies to routes with domains",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
@@ -146,12 +153,19 @@ func (r *Route) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 				MarkdownDescription: "Access control group identifier associated with route.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Validators:          []validator.List{listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
 			},
 			"skip_auto_apply": schema.BoolAttribute{
 				MarkdownDescription: "Indicate if this exit node route (0.0.0.0/0) should skip auto-application for client routing",
 				Optional:            true,
 				Computed:            true,
 			},
+			"validate_groups": schema.BoolAttribute{
+				MarkdownDescription: "Check that groups, peer_groups and access_control_groups exist before creating or updating the route, requires an additional API call",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -162,18 +176,18 @@ func (r *Route) Configure(ctx context.Context, req resource.ConfigureRequest, re
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func routeAPIToTerraform(ctx context.Context, route *api.Route, data *RouteModel) diag.Diagnostics {
@@ -181,7 +195,7 @@ func routeAPIToTerraform(ctx context.Context, route *api.Route, data *RouteModel
 	var d diag.Diagnostics
 	data.Id = types.StringValue(route.Id)
 	data.NetworkType = types.StringValue(route.NetworkType)
-	data.Description = types.StringValue(route.Description)
+	data.Description = normalizeDescription(&route.Description)
 	data.NetworkId = types.StringValue(route.NetworkId)
 	data.Enabled = types.BoolValue(route.Enabled)
 	if route.Peer != nil && *route.Peer != "" {
@@ -211,8 +225,11 @@ func routeAPIToTerraform(ctx context.Context, route *api.Route, data *RouteModel
 	}
 	data.Groups, d = types.ListValueFrom(ctx, types.StringType, route.Groups)
 	ret.Append(d...)
-	if route.AccessControlGroups == nil {
-		data.AccessControlGroups = types.ListNull(types.StringType)
+	if route.AccessControlGroups == nil || len(*route.AccessControlGroups) == 0 {
+		// Keep an explicitly empty list from configuration, the API doesn't distinguish it from unset
+		if data.AccessControlGroups.IsNull() || data.AccessControlGroups.IsUnknown() || len(data.AccessControlGroups.Elements()) > 0 {
+			data.AccessControlGroups = types.ListNull(types.StringType)
+		}
 	} else {
 		data.AccessControlGroups, d = types.ListValueFrom(ctx, types.StringType, route.AccessControlGroups)
 		ret.Append(d...)
@@ -221,8 +238,52 @@ func routeAPIToTerraform(ctx context.Context, route *api.Route, data *RouteModel
 	return ret
 }
 
+// routeConfigValidator warns about keep_route set on network routes, where it has no effect.
+type routeConfigValidator struct{}
+
+func (v routeConfigValidator) Description(ctx context.Context) string {
+	return "keep_route only applies to domain routes"
+}
+
+func (v routeConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v routeConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RouteResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// keep_route defaults to true, only warn when it is set explicitly
+	if data.Network.IsNull() || data.Network.IsUnknown() || !data.KeepRoute.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("keep_route"),
+		"Ineffective keep_route",
+		"keep_route only keeps routes to IPs previously resolved for domains, it has no effect on routes with a network range.",
+	)
+}
+
+func (r *Route) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("network"), path.MatchRoot("domains")),
+		routeConfigValidator{},
+	}
+}
+
+// routeGroupIDs returns all group IDs referenced by the route.
+func routeGroupIDs(ctx context.Context, data RouteModel) []string {
+	ids := stringListDefault(ctx, data.Groups, []string{})
+	ids = append(ids, stringListDefault(ctx, data.PeerGroups, []string{})...)
+	return append(ids, stringListDefault(ctx, data.AccessControlGroups, []string{})...)
+}
+
 func (r *Route) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data RouteModel
+	var data RouteResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -231,6 +292,13 @@ func (r *Route) Create(ctx context.Context, req resource.CreateRequest, resp *re
 		return
 	}
 
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, routeGroupIDs(ctx, data.RouteModel))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	routeReq := api.RouteRequest{
 		AccessControlGroups: stringListDefaultPointer(ctx, data.AccessControlGroups, nil),
 		Description:         data.Description.ValueString(),
@@ -249,11 +317,11 @@ func (r *Route) Create(ctx context.Context, req resource.CreateRequest, resp *re
 
 	route, err := r.client.Routes.Create(ctx, routeReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating route", err.Error())
+		resp.Diagnostics.AddError("Error creating route", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(routeAPIToTerraform(ctx, route, &data)...)
+	resp.Diagnostics.Append(routeAPIToTerraform(ctx, route, &data.RouteModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -264,7 +332,7 @@ func (r *Route) Create(ctx context.Context, req resource.CreateRequest, resp *re
 }
 
 func (r *Route) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data RouteModel
+	var data RouteResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -279,12 +347,12 @@ func (r *Route) Read(ctx context.Context, req resource.ReadRequest, resp *resour
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting Route", err.Error())
+			resp.Diagnostics.AddError("Error getting Route", formatAPIError(err))
 		}
 		return
 	}
 
-	resp.Diagnostics.Append(routeAPIToTerraform(ctx, route, &data)...)
+	resp.Diagnostics.Append(routeAPIToTerraform(ctx, route, &data.RouteModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -295,7 +363,7 @@ func (r *Route) Read(ctx context.Context, req resource.ReadRequest, resp *resour
 }
 
 func (r *Route) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data RouteModel
+	var data RouteResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -309,6 +377,13 @@ func (r *Route) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 		return
 	}
 
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, routeGroupIDs(ctx, data.RouteModel))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	routeReq := api.RouteRequest{
 		AccessControlGroups: stringListDefaultPointer(ctx, data.AccessControlGroups, nil),
 		Description:         data.Description.ValueString(),
@@ -327,11 +402,11 @@ func (r *Route) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 
 	route, err := r.client.Routes.Update(ctx, data.Id.ValueString(), routeReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Route", err.Error())
+		resp.Diagnostics.AddError("Error updating Route", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(routeAPIToTerraform(ctx, route, &data)...)
+	resp.Diagnostics.Append(routeAPIToTerraform(ctx, route, &data.RouteModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -341,7 +416,7 @@ func (r *Route) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 }
 
 func (r *Route) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data RouteModel
+	var data RouteResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -351,11 +426,12 @@ func (r *Route) Delete(ctx context.Context, req resource.DeleteRequest, resp *re
 	}
 
 	err := r.client.Routes.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting Route", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Route", formatAPIError(err))
 	}
 }
 
 func (r *Route) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("validate_groups"), false)...)
 }
@@ -2,14 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/netbirdio/netbird/shared/management/http/api"
 )
@@ -17,6 +22,7 @@ import (
 func Test_routeAPIToTerraform(t *testing.T) {
 	cases := []struct {
 		resource *api.Route
+		state    RouteModel
 		expected RouteModel
 	}{
 		{
@@ -84,10 +90,100 @@ func Test_routeAPIToTerraform(t *testing.T) {
 				SkipAutoApply:       types.BoolValue(true),
 			},
 		},
+		{
+			resource: &api.Route{
+				Id:                  "r3",
+				Network:             valPtr("10.0.0.0/24"),
+				Groups:              []string{"g1"},
+				NetworkId:           "acg-empty",
+				NetworkType:         "IPv4",
+				PeerGroups:          &[]string{"g2"},
+				AccessControlGroups: nil,
+			},
+			state: RouteModel{
+				AccessControlGroups: types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+			expected: RouteModel{
+				Id:                  types.StringValue("r3"),
+				Description:         types.StringValue(""),
+				Enabled:             types.BoolValue(false),
+				KeepRoute:           types.BoolValue(false),
+				Masquerade:          types.BoolValue(false),
+				NetworkId:           types.StringValue("acg-empty"),
+				NetworkType:         types.StringValue("IPv4"),
+				PeerGroups:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g2")}),
+				Domains:             types.ListNull(types.StringType),
+				Metric:              types.Int32Value(0),
+				Groups:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+				Peer:                types.StringNull(),
+				Network:             types.StringValue("10.0.0.0/24"),
+				AccessControlGroups: types.ListValueMust(types.StringType, []attr.Value{}),
+				SkipAutoApply:       types.BoolNull(),
+			},
+		},
+		{
+			resource: &api.Route{
+				Id:                  "r4",
+				Network:             valPtr("10.0.0.0/24"),
+				Groups:              []string{"g1"},
+				NetworkId:           "acg-cleared",
+				NetworkType:         "IPv4",
+				PeerGroups:          &[]string{"g2"},
+				AccessControlGroups: &[]string{},
+			},
+			state: RouteModel{
+				AccessControlGroups: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g3")}),
+			},
+			expected: RouteModel{
+				Id:                  types.StringValue("r4"),
+				Description:         types.StringValue(""),
+				Enabled:             types.BoolValue(false),
+				KeepRoute:           types.BoolValue(false),
+				Masquerade:          types.BoolValue(false),
+				NetworkId:           types.StringValue("acg-cleared"),
+				NetworkType:         types.StringValue("IPv4"),
+				PeerGroups:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g2")}),
+				Domains:             types.ListNull(types.StringType),
+				Metric:              types.Int32Value(0),
+				Groups:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+				Peer:                types.StringNull(),
+				Network:             types.StringValue("10.0.0.0/24"),
+				AccessControlGroups: types.ListNull(types.StringType),
+				SkipAutoApply:       types.BoolNull(),
+			},
+		},
+		{
+			resource: &api.Route{
+				Id:                  "r5",
+				Network:             valPtr("10.0.0.0/24"),
+				Groups:              []string{"g1"},
+				NetworkId:           "acg",
+				NetworkType:         "IPv4",
+				PeerGroups:          &[]string{"g2"},
+				AccessControlGroups: &[]string{"g3", "g4"},
+			},
+			expected: RouteModel{
+				Id:                  types.StringValue("r5"),
+				Description:         types.StringValue(""),
+				Enabled:             types.BoolValue(false),
+				KeepRoute:           types.BoolValue(false),
+				Masquerade:          types.BoolValue(false),
+				NetworkId:           types.StringValue("acg"),
+				NetworkType:         types.StringValue("IPv4"),
+				PeerGroups:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g2")}),
+				Domains:             types.ListNull(types.StringType),
+				Metric:              types.Int32Value(0),
+				Groups:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+				Peer:                types.StringNull(),
+				Network:             types.StringValue("10.0.0.0/24"),
+				AccessControlGroups: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g3"), types.StringValue("g4")}),
+				SkipAutoApply:       types.BoolNull(),
+			},
+		},
 	}
 
 	for _, c := range cases {
-		var out RouteModel
+		out := c.state
 		outDiag := routeAPIToTerraform(context.Background(), c.resource, &out)
 		if outDiag.HasError() {
 			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
@@ -99,6 +195,51 @@ func Test_routeAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_routeConfigValidator(t *testing.T) {
+	cases := []struct {
+		name      string
+		network   types.String
+		domains   types.List
+		keepRoute types.Bool
+		warnings  int
+		errors    int
+	}{
+		{name: "network with keep_route", network: types.StringValue("10.0.0.0/24"), domains: types.ListNull(types.StringType), keepRoute: types.BoolValue(true), warnings: 1},
+		{name: "network without keep_route", network: types.StringValue("10.0.0.0/24"), domains: types.ListNull(types.StringType), keepRoute: types.BoolNull(), warnings: 0},
+		{name: "network with keep_route disabled", network: types.StringValue("10.0.0.0/24"), domains: types.ListNull(types.StringType), keepRoute: types.BoolValue(false), warnings: 0},
+		{name: "domains with keep_route", network: types.StringNull(), domains: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("example.com")}), keepRoute: types.BoolValue(true), warnings: 0},
+		{name: "neither network nor domains", network: types.StringNull(), domains: types.ListNull(types.StringType), keepRoute: types.BoolNull(), errors: 1},
+		{name: "network and domains", network: types.StringValue("10.0.0.0/24"), domains: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("example.com")}), keepRoute: types.BoolNull(), errors: 1},
+	}
+
+	r := &Route{}
+	for _, c := range cases {
+		state := testResourceState(t, r, &RouteResourceModel{
+			RouteModel: RouteModel{
+				NetworkId:           types.StringValue("net"),
+				Network:             c.network,
+				Domains:             c.domains,
+				KeepRoute:           c.keepRoute,
+				Groups:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+				PeerGroups:          types.ListNull(types.StringType),
+				AccessControlGroups: types.ListNull(types.StringType),
+			},
+			ValidateGroups: types.BoolNull(),
+		})
+
+		resp := tfresource.ValidateConfigResponse{}
+		for _, v := range r.ConfigValidators(context.Background()) {
+			v.ValidateResource(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+		}
+		if resp.Diagnostics.ErrorsCount() != c.errors {
+			t.Fatalf("Expected %d errors for %s, found %v", c.errors, c.name, resp.Diagnostics.Errors())
+		}
+		if resp.Diagnostics.WarningsCount() != c.warnings {
+			t.Fatalf("Expected %d warnings for %s, found %d", c.warnings, c.name, resp.Diagnostics.WarningsCount())
+		}
+	}
+}
+
 func Test_Route_Create(t *testing.T) {
 	rName := "pc" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_route." + rName
@@ -201,6 +342,105 @@ func Test_Route_Update(t *testing.T) {
 	})
 }
 
+func Test_Route_Update_disable(t *testing.T) {
+	var requests []string
+	var updated api.RouteRequest
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"r1","network_id":"net","network":"10.0.0.0/16","network_type":"IPv4","enabled":false,"groups":["g1"],"metric":9999,"masquerade":true,"keep_route":false,"description":""}`))
+	})
+
+	r := &Route{client: client}
+	plan := testResourceState(t, r, &RouteResourceModel{
+		RouteModel: RouteModel{
+			Id:                  types.StringValue("r1"),
+			NetworkId:           types.StringValue("net"),
+			Network:             types.StringValue("10.0.0.0/16"),
+			Enabled:             types.BoolValue(false),
+			Metric:              types.Int32Value(9999),
+			Masquerade:          types.BoolValue(true),
+			Groups:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+			Domains:             types.ListNull(types.StringType),
+			PeerGroups:          types.ListNull(types.StringType),
+			AccessControlGroups: types.ListNull(types.StringType),
+		},
+		ValidateGroups: types.BoolValue(false),
+	})
+	resp := tfresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Update(context.Background(), tfresource.UpdateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}, State: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+
+	if !reflect.DeepEqual(requests, []string{"PUT /api/routes/r1"}) || updated.Enabled {
+		t.Fatalf("Expected a single update disabling the route, found %v with enabled=%t", requests, updated.Enabled)
+	}
+	var out RouteResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+	if out.Id.ValueString() != "r1" || out.Enabled.ValueBool() {
+		t.Fatalf("Expected route r1 to be kept disabled, found %s with enabled=%t", out.Id.ValueString(), out.Enabled.ValueBool())
+	}
+}
+
+func Test_Route_Update_enabled(t *testing.T) {
+	rName := "pc" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_route." + rName
+	var routeID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: rName,
+				Config:       testRouteEnabledResource(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "enabled", "true"),
+					func(s *terraform.State) error {
+						routeID = s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName: rName,
+				Config:       testRouteEnabledResource(rName, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(rNameFull, plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(rNameFull, "enabled", "false"),
+					func(s *terraform.State) error {
+						pID := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+						route, err := testClient().Routes.Get(context.Background(), pID)
+						if err != nil {
+							return err
+						}
+						return matchPairs(map[string][]any{
+							"id":      {routeID, route.Id},
+							"enabled": {false, route.Enabled},
+						})
+					},
+				),
+			},
+		},
+	})
+}
+
+func testRouteEnabledResource(rName string, enabled bool) string {
+	return fmt.Sprintf(`resource "netbird_route" "%s" {
+  network_id = "%s"
+  groups     = ["group-all"]
+  network    = "100.11.0.0/16"
+  peer       = "peer1"
+  enabled    = %t
+}
+`, rName, rName, enabled)
+}
+
 func testRouteResource(rName, groups, aclGroups, description, network, domains, peerGroups, peer string) string {
 	return fmt.Sprintf(`resource "netbird_route" "%s" {
   network_id            = "%s"
@@ -84,16 +84,16 @@ func (d *ScimDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func (d *ScimDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -111,7 +111,7 @@ func (d *ScimDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	scims, err := d.client.SCIM.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing SCIM integrations", err.Error())
+		resp.Diagnostics.AddError("Error listing SCIM integrations", formatAPIError(err))
 		return
 	}
 
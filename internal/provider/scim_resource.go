@@ -107,16 +107,16 @@ func (r *Scim) Configure(ctx context.Context, req resource.ConfigureRequest, res
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func scimAPIToTerraform(ctx context.Context, scim *api.ScimIntegration, data *ScimModel) diag.Diagnostics {
@@ -151,7 +151,7 @@ func (r *Scim) Create(ctx context.Context, req resource.CreateRequest, resp *res
 
 	scim, err := r.client.SCIM.Create(ctx, scimReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating SCIM integration", err.Error())
+		resp.Diagnostics.AddError("Error creating SCIM integration", formatAPIError(err))
 		return
 	}
 
@@ -180,7 +180,7 @@ func (r *Scim) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error getting SCIM integration", err.Error())
+		resp.Diagnostics.AddError("Error getting SCIM integration", formatAPIError(err))
 		return
 	}
 
@@ -215,7 +215,7 @@ func (r *Scim) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 
 	scim, err := r.client.SCIM.Update(ctx, data.Id.ValueString(), scimReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating SCIM integration", err.Error())
+		resp.Diagnostics.AddError("Error updating SCIM integration", formatAPIError(err))
 		return
 	}
 
@@ -246,8 +246,8 @@ func (r *Scim) Delete(ctx context.Context, req resource.DeleteRequest, resp *res
 	}
 
 	err := r.client.SCIM.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting SCIM integration", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting SCIM integration", formatAPIError(err))
 	}
 }
 
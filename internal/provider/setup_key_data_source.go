@@ -68,7 +68,7 @@ This can **not** read the plain SetupKey.`,
 				Computed:            true,
 			},
 			"expires": schema.StringAttribute{
-				MarkdownDescription: "SetupKey Expiration Date",
+				MarkdownDescription: "SetupKey Expiration Date, null if the setup key never expires",
 				Computed:            true,
 			},
 			"updated_at": schema.StringAttribute{
@@ -76,7 +76,7 @@ This can **not** read the plain SetupKey.`,
 				Computed:            true,
 			},
 			"last_used": schema.StringAttribute{
-				MarkdownDescription: "Last usage time",
+				MarkdownDescription: "Last usage time, null if the setup key was never used",
 				Computed:            true,
 			},
 			"type": schema.StringAttribute{
@@ -126,27 +126,27 @@ func (d *SetupKeyDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func setupKeyDataSourceAPIToTerraform(ctx context.Context, setupKey *api.SetupKey, data *SetupKeyDataSourceModel) diag.Diagnostics {
 	var ret diag.Diagnostics
 	data.Id = types.StringValue(setupKey.Id)
 	data.Name = types.StringValue(setupKey.Name)
-	data.Expires = types.StringValue(setupKey.Expires.Format(time.RFC3339))
+	data.Expires = timeStringOrNull(setupKey.Expires)
 	data.UpdatedAt = types.StringValue(setupKey.UpdatedAt.Format(time.RFC3339))
-	data.LastUsed = types.StringValue(setupKey.LastUsed.Format(time.RFC3339))
+	data.LastUsed = setupKeyLastUsed(setupKey)
 	data.AllowExtraDnsLabels = types.BoolValue(setupKey.AllowExtraDnsLabels)
 	l, diag := types.ListValueFrom(ctx, types.StringType, setupKey.AutoGroups)
 	ret.Append(diag...)
@@ -178,7 +178,7 @@ func (d *SetupKeyDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	setupKeys, err := d.client.SetupKeys.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing SetupKeys", err.Error())
+		resp.Diagnostics.AddError("Error listing SetupKeys", formatAPIError(err))
 		return
 	}
 
@@ -7,14 +7,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
@@ -32,6 +36,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SetupKey{}
 var _ resource.ResourceWithImportState = &SetupKey{}
+var _ resource.ResourceWithConfigValidators = &SetupKey{}
+var _ resource.ResourceWithModifyPlan = &SetupKey{}
 
 func NewSetupKey() resource.Resource {
 	return &SetupKey{}
@@ -40,12 +46,19 @@ func NewSetupKey() resource.Resource {
 // SetupKey defines the resource implementation.
 type SetupKey struct {
 	client *netbird.Client
+
+	// defaultAutoGroups are the auto groups of setup keys created without auto_groups.
+	defaultAutoGroups []string
+
+	// nameTemplate derives the name of created setup keys from their configured name, nil to use it unchanged.
+	nameTemplate *template.Template
 }
 
 // SetupKeyModel describes the resource data model.
 type SetupKeyModel struct {
 	Id                  types.String `tfsdk:"id"`
 	Name                types.String `tfsdk:"name"`
+	ServerName          types.String `tfsdk:"server_name"`
 	Expires             types.String `tfsdk:"expires"`
 	UpdatedAt           types.String `tfsdk:"updated_at"`
 	LastUsed            types.String `tfsdk:"last_used"`
@@ -53,13 +66,18 @@ type SetupKeyModel struct {
 	Type                types.String `tfsdk:"type"`
 	UsageLimit          types.Int32  `tfsdk:"usage_limit"`
 	UsedTimes           types.Int32  `tfsdk:"used_times"`
+	RemainingUses       types.Int32  `tfsdk:"remaining_uses"`
 	ExpirySeconds       types.Int32  `tfsdk:"expiry_seconds"`
+	ExpiryDays          types.Int32  `tfsdk:"expiry_days"`
 	State               types.String `tfsdk:"state"`
 	AutoGroups          types.List   `tfsdk:"auto_groups"`
+	AutoGroupsResolved  types.List   `tfsdk:"auto_groups_resolved"`
 	Ephemeral           types.Bool   `tfsdk:"ephemeral"`
 	AllowExtraDnsLabels types.Bool   `tfsdk:"allow_extra_dns_labels"`
 	Valid               types.Bool   `tfsdk:"valid"`
 	Revoked             types.Bool   `tfsdk:"revoked"`
+	ValidateGroups      types.Bool   `tfsdk:"validate_groups"`
+	ResolveAutoGroups   types.Bool   `tfsdk:"resolve_auto_groups"`
 }
 
 func (r *SetupKey) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -78,13 +96,17 @@ func (r *SetupKey) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "SetupKey Name",
+				MarkdownDescription: "SetupKey Name, changing it replaces the setup key unless the provider `setup_key_name_template` renders it to the current `server_name`",
 				Required:            true,
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
 				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
 			},
+			"server_name": schema.StringAttribute{
+				MarkdownDescription: "SetupKey Name on the server, derived from `name` by the provider `setup_key_name_template` when the setup key was created",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
 			"expires": schema.StringAttribute{
-				MarkdownDescription: "SetupKey Expiration Date",
+				MarkdownDescription: "SetupKey Expiration Date, null if the setup key never expires",
 				Computed:            true,
 			},
 			"expiry_seconds": schema.Int32Attribute{
@@ -94,17 +116,26 @@ func (r *SetupKey) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Default:             int32default.StaticInt32(0),
 				PlanModifiers:       []planmodifier.Int32{int32planmodifier.RequiresReplace()},
 			},
+			"expiry_days": schema.Int32Attribute{
+				MarkdownDescription: "Expiry time in days, Conflicts with expiry_seconds",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Int32{int32planmodifier.RequiresReplace()},
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+					int32validator.ConflictsWith(path.MatchRoot("expiry_seconds")),
+				},
+			},
 			"updated_at": schema.StringAttribute{
 				MarkdownDescription: "Creation timestamp",
 				Computed:            true,
 			},
 			"last_used": schema.StringAttribute{
-				MarkdownDescription: "Last usage time",
+				MarkdownDescription: "Last usage time, null if the setup key was never used",
 				Computed:            true,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 			"key": schema.StringAttribute{
-				MarkdownDescription: "Plaintext setup key",
+				MarkdownDescription: "Plaintext setup key, only returned by the API when the setup key is created, null for imported setup keys",
 				Computed:            true,
 				Sensitive:           true,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
@@ -129,18 +160,29 @@ func (r *SetupKey) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Computed:            true,
 				PlanModifiers:       []planmodifier.Int32{int32planmodifier.UseStateForUnknown()},
 			},
+			"remaining_uses": schema.Int32Attribute{
+				MarkdownDescription: "Number of times Setup Key can still be used, null if usage is unlimited",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.Int32{int32planmodifier.UseStateForUnknown()},
+			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "Setup key state (valid or expired)",
 				Computed:            true,
 			},
 			"auto_groups": schema.ListAttribute{
-				MarkdownDescription: "List of groups to automatically assign to peers created through this setup key",
+				MarkdownDescription: "List of groups to automatically assign to peers created through this setup key, defaults to the provider `default_auto_groups` when creating the setup key",
 				Computed:            true,
 				Optional:            true,
 				ElementType:         types.StringType,
 				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()},
 				Validators:          []validator.List{listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
 			},
+			"auto_groups_resolved": schema.ListAttribute{
+				MarkdownDescription: "Names of the groups in auto_groups, in the same order, only set when resolve_auto_groups is enabled, null if any of the groups does not exist or the groups can not be listed",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown(), setupKeyAutoGroupsResolvedPlanModifier{}},
+			},
 			"ephemeral": schema.BoolAttribute{
 				MarkdownDescription: "Indicate that the peer will be ephemeral or not, ephemeral peers are deleted after 10 minutes of inactivity",
 				Computed:            true,
@@ -158,10 +200,25 @@ func (r *SetupKey) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Computed:            true,
 			},
 			"revoked": schema.BoolAttribute{
-				MarkdownDescription: "Set to true to revoke setup key",
+				MarkdownDescription: "Set to true to revoke setup key, revoked setup keys can not be restored, setting it back to false replaces the setup key with a new one",
 				Computed:            true,
 				Optional:            true,
-				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplaceIf(setupKeyUnrevoked, "Revoked setup keys can not be restored, setting revoked to false requires replacement", "Revoked setup keys can not be restored, setting `revoked` to false requires replacement"),
+				},
+			},
+			"validate_groups": schema.BoolAttribute{
+				MarkdownDescription: "Check that auto_groups exist before creating or updating the setup key, requires an additional API call",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"resolve_auto_groups": schema.BoolAttribute{
+				MarkdownDescription: "Set auto_groups_resolved to the names of the groups in auto_groups, requires an additional API call whenever the setup key is read",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 		},
 	}
@@ -173,27 +230,83 @@ func (r *SetupKey) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.defaultAutoGroups = data.defaultAutoGroups
+	r.nameTemplate = data.setupKeyNameTemplate
+}
+
+// setupKeyLastUsed returns the last usage time of the setup key, null if the key was never used.
+func setupKeyLastUsed(setupKey *api.SetupKey) types.String {
+	if setupKey.UsedTimes == 0 {
+		return types.StringNull()
+	}
+	return timeStringOrNull(setupKey.LastUsed)
+}
+
+// setupKeyNameTemplateData is the data setup key name templates are executed with.
+type setupKeyNameTemplateData struct {
+	// Name is the configured setup key name
+	Name string
+}
+
+// parseSetupKeyNameTemplate parses a setup key name template and checks it executes, an empty template returns nil.
+func parseSetupKeyNameTemplate(s string) (*template.Template, error) {
+	if s == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("setup_key_name_template").Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = setupKeyName(tmpl, "example"); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// setupKeyName returns the name of the setup key on the server for the configured name.
+func setupKeyName(tmpl *template.Template, name string) (string, error) {
+	if tmpl == nil {
+		return name, nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, setupKeyNameTemplateData{Name: name}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// setupKeyKeepConfiguredName moves the name on the server to server_name and keeps the configured name,
+// imported setup keys have no configured name and use the name on the server.
+func setupKeyKeepConfiguredName(name types.String, data *SetupKeyModel) {
+	data.ServerName = data.Name
+	if !name.IsNull() && !name.IsUnknown() {
+		data.Name = name
+	}
 }
 
 func setupKeyAPIToTerraform(ctx context.Context, setupKey *api.SetupKey, data *SetupKeyModel) diag.Diagnostics {
 	var ret diag.Diagnostics
 	data.Id = types.StringValue(setupKey.Id)
 	data.Name = types.StringValue(setupKey.Name)
-	data.Expires = types.StringValue(setupKey.Expires.Format(time.RFC3339))
+	data.Expires = timeStringOrNull(setupKey.Expires)
+	// Setup keys created without expiry_days and with expiry_seconds 0 never expire
+	if data.ExpiryDays.IsNull() && !data.ExpirySeconds.IsNull() && !data.ExpirySeconds.IsUnknown() && data.ExpirySeconds.ValueInt32() == 0 {
+		data.Expires = types.StringNull()
+	}
 	data.UpdatedAt = types.StringValue(setupKey.UpdatedAt.Format(time.RFC3339))
-	data.LastUsed = types.StringValue(setupKey.LastUsed.Format(time.RFC3339))
+	data.LastUsed = setupKeyLastUsed(setupKey)
 	data.AllowExtraDnsLabels = types.BoolValue(setupKey.AllowExtraDnsLabels)
 	l, diag := types.ListValueFrom(ctx, types.StringType, setupKey.AutoGroups)
 	ret.Append(diag...)
@@ -204,10 +317,133 @@ func setupKeyAPIToTerraform(ctx context.Context, setupKey *api.SetupKey, data *S
 	data.Type = types.StringValue(setupKey.Type)
 	data.UsageLimit = types.Int32Value(int32(setupKey.UsageLimit))
 	data.UsedTimes = types.Int32Value(int32(setupKey.UsedTimes))
+	data.RemainingUses = types.Int32Null()
+	if setupKey.UsageLimit > 0 {
+		data.RemainingUses = types.Int32Value(int32(max(setupKey.UsageLimit-setupKey.UsedTimes, 0)))
+	}
 	data.Valid = types.BoolValue(setupKey.Valid)
 	return ret
 }
 
+var _ planmodifier.List = setupKeyAutoGroupsResolvedPlanModifier{}
+
+// setupKeyAutoGroupsResolvedPlanModifier plans auto_groups_resolved as unknown when auto_groups or resolve_auto_groups change.
+type setupKeyAutoGroupsResolvedPlanModifier struct{}
+
+func (m setupKeyAutoGroupsResolvedPlanModifier) Description(ctx context.Context) string {
+	return "auto_groups_resolved is recomputed when auto_groups or resolve_auto_groups change"
+}
+
+func (m setupKeyAutoGroupsResolvedPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m setupKeyAutoGroupsResolvedPlanModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plannedGroups, priorGroups types.List
+	var plannedResolve, priorResolve types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auto_groups"), &plannedGroups)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("auto_groups"), &priorGroups)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("resolve_auto_groups"), &plannedResolve)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("resolve_auto_groups"), &priorResolve)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plannedGroups.Equal(priorGroups) || !plannedResolve.Equal(priorResolve) {
+		resp.PlanValue = types.ListUnknown(types.StringType)
+	}
+}
+
+// setupKeyResolveAutoGroups sets auto_groups_resolved to the names of the auto groups of the setup key when resolve_auto_groups is enabled,
+// failing to list the groups is a warning leaving auto_groups_resolved null.
+func setupKeyResolveAutoGroups(ctx context.Context, client *netbird.Client, data *SetupKeyModel) diag.Diagnostics {
+	var ret diag.Diagnostics
+	if !data.ResolveAutoGroups.ValueBool() {
+		data.AutoGroupsResolved = types.ListNull(types.StringType)
+		return ret
+	}
+	ids := stringListDefault(ctx, data.AutoGroups, []string{})
+	data.AutoGroupsResolved = types.ListValueMust(types.StringType, []attr.Value{})
+	if len(ids) == 0 {
+		return ret
+	}
+
+	groups, err := client.Groups.List(ctx)
+	if err != nil {
+		ret.AddAttributeWarning(path.Root("auto_groups_resolved"), "Error listing Groups", fmt.Sprintf("auto_groups_resolved is left unset, %s", formatAPIError(err)))
+		data.AutoGroupsResolved = types.ListNull(types.StringType)
+		return ret
+	}
+	names := make(map[string]string, len(groups))
+	for _, g := range groups {
+		names[g.Id] = g.Name
+	}
+
+	resolved := make([]attr.Value, len(ids))
+	for i, id := range ids {
+		name, ok := names[id]
+		if !ok {
+			data.AutoGroupsResolved = types.ListNull(types.StringType)
+			return ret
+		}
+		resolved[i] = types.StringValue(name)
+	}
+	data.AutoGroupsResolved = types.ListValueMust(types.StringType, resolved)
+	return ret
+}
+
+// setupKeyConfigValidator warns about reusable setup keys without a usage limit, as these can register unlimited peers.
+type setupKeyConfigValidator struct{}
+
+func (v setupKeyConfigValidator) Description(ctx context.Context) string {
+	return "reusable setup keys should set usage_limit"
+}
+
+func (v setupKeyConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v setupKeyConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SetupKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Type.ValueString() != "reusable" || data.UsageLimit.IsUnknown() || data.UsageLimit.ValueInt32() != 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("usage_limit"),
+		"Unlimited Reusable Setup Key",
+		"Reusable setup key has no usage_limit and can be used to register an unlimited number of peers, consider setting usage_limit to the number of expected peers.",
+	)
+}
+
+func (r *SetupKey) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{setupKeyConfigValidator{}}
+}
+
+// setupKeyUnrevoked requires replacement when a revoked setup key is planned as not revoked, as the API can not restore it.
+func setupKeyUnrevoked(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() || req.PlanValue.IsNull() {
+		return
+	}
+	resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.ValueBool()
+}
+
+// setupKeyExpiresIn returns the setup key expiry in seconds from either expiry_days or expiry_seconds.
+func setupKeyExpiresIn(data SetupKeyModel) int {
+	if !data.ExpiryDays.IsNull() && !data.ExpiryDays.IsUnknown() {
+		return int(data.ExpiryDays.ValueInt32()) * int((24 * time.Hour).Seconds())
+	}
+	return int(data.ExpirySeconds.ValueInt32())
+}
+
 func (r *SetupKey) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SetupKeyModel
 
@@ -218,22 +454,45 @@ func (r *SetupKey) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	// auto_groups is unknown when not configured, use the provider default auto groups then
+	autoGroups := stringListDefault(ctx, data.AutoGroups, r.defaultAutoGroups)
+	if autoGroups == nil {
+		autoGroups = []string{}
+	}
+
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, autoGroups)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	name, err := setupKeyName(r.nameTemplate, data.Name.ValueString())
+	if err == nil && name == "" {
+		err = fmt.Errorf("setup_key_name_template produced an empty name for %q", data.Name.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Setup Key Name", err.Error())
+		return
+	}
+
 	createRequest := api.CreateSetupKeyRequest{
 		AllowExtraDnsLabels: data.AllowExtraDnsLabels.ValueBoolPointer(),
-		AutoGroups:          stringListDefault(ctx, data.AutoGroups, []string{}),
+		AutoGroups:          autoGroups,
 		Ephemeral:           data.Ephemeral.ValueBoolPointer(),
-		ExpiresIn:           int(data.ExpirySeconds.ValueInt32()),
-		Name:                data.Name.ValueString(),
+		ExpiresIn:           setupKeyExpiresIn(data),
+		Name:                name,
 		Type:                data.Type.ValueString(),
 		UsageLimit:          int(data.UsageLimit.ValueInt32()),
 	}
 
 	setupKey, err := r.client.SetupKeys.Create(ctx, createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating SetupKey", err.Error())
+		resp.Diagnostics.AddError("Error creating SetupKey", formatAPIError(err))
 		return
 	}
 
+	configuredName := data.Name
 	resp.Diagnostics.Append(setupKeyAPIToTerraform(ctx, &api.SetupKey{
 		AllowExtraDnsLabels: setupKey.AllowExtraDnsLabels,
 		AutoGroups:          setupKey.AutoGroups,
@@ -251,13 +510,11 @@ func (r *SetupKey) Create(ctx context.Context, req resource.CreateRequest, resp
 		UsedTimes:           setupKey.UsedTimes,
 		Valid:               setupKey.Valid,
 	}, &data)...)
+	setupKeyKeepConfiguredName(configuredName, &data)
 	data.Key = types.StringValue(setupKey.Key)
+	resp.Diagnostics.Append(setupKeyResolveAutoGroups(ctx, r.client, &data)...)
 
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// Save data into Terraform state
+	// Save data into Terraform state even on errors, the plaintext key is only returned on creation
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -278,12 +535,15 @@ func (r *SetupKey) Read(ctx context.Context, req resource.ReadRequest, resp *res
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting SetupKey", err.Error())
+			resp.Diagnostics.AddError("Error getting SetupKey", formatAPIError(err))
 		}
 		return
 	}
 
+	configuredName := data.Name
 	resp.Diagnostics.Append(setupKeyAPIToTerraform(ctx, setupKey, &data)...)
+	setupKeyKeepConfiguredName(configuredName, &data)
+	resp.Diagnostics.Append(setupKeyResolveAutoGroups(ctx, r.client, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -307,17 +567,27 @@ func (r *SetupKey) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, stringListDefault(ctx, data.AutoGroups, []string{}))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	setupKey, err := r.client.SetupKeys.Update(ctx, data.Id.ValueString(), api.SetupKeyRequest{
 		AutoGroups: stringListDefault(ctx, data.AutoGroups, []string{}),
 		Revoked:    data.Revoked.ValueBool(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Setup Key", err.Error())
+		resp.Diagnostics.AddError("Error updating Setup Key", formatAPIError(err))
 		return
 	}
 
+	configuredName := data.Name
 	resp.Diagnostics.Append(setupKeyAPIToTerraform(ctx, setupKey, &data)...)
+	setupKeyKeepConfiguredName(configuredName, &data)
+	resp.Diagnostics.Append(setupKeyResolveAutoGroups(ctx, r.client, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -327,6 +597,30 @@ func (r *SetupKey) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 }
 
+func (r *SetupKey) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only changes to existing setup keys can require replacement
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state SetupKeyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Name.Equal(state.Name) {
+		return
+	}
+
+	// Setup key names can not be updated, renaming is free only when the new name renders to the name on the server,
+	// e.g. for imported setup keys or a configured name matching the server name
+	if !plan.Name.IsUnknown() {
+		name, err := setupKeyName(r.nameTemplate, plan.Name.ValueString())
+		if err == nil && name == state.ServerName.ValueString() {
+			return
+		}
+	}
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("name"))
+}
+
 func (r *SetupKey) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data SetupKeyModel
 
@@ -338,11 +632,14 @@ func (r *SetupKey) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	}
 
 	err := r.client.SetupKeys.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting SetupKey", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting SetupKey", formatAPIError(err))
 	}
 }
 
 func (r *SetupKey) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("validate_groups"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolve_auto_groups"), false)...)
+	resp.Diagnostics.AddAttributeWarning(path.Root("key"), "Setup Key Not Imported", "The plaintext setup key is only returned when the setup key is created, key is null for imported setup keys.")
 }
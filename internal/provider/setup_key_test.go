@@ -2,15 +2,25 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/netbirdio/netbird/shared/management/http/api"
 )
@@ -20,6 +30,7 @@ func Test_setupKeyAPIToTerraform(t *testing.T) {
 
 	cases := []struct {
 		resource *api.SetupKey
+		data     SetupKeyModel
 		expected SetupKeyModel
 	}{
 		{
@@ -55,13 +66,120 @@ func Test_setupKeyAPIToTerraform(t *testing.T) {
 				UpdatedAt:           types.StringValue(timeNow.Format(time.RFC3339)),
 				UsageLimit:          types.Int32Value(0),
 				UsedTimes:           types.Int32Value(1),
+				RemainingUses:       types.Int32Null(),
 				AutoGroups:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
 			},
 		},
+		{
+			resource: &api.SetupKey{
+				Id:         "r2",
+				AutoGroups: []string{},
+				Expires:    timeNow,
+				LastUsed:   timeNow,
+				Name:       "sk",
+				State:      "active",
+				Type:       "reusable",
+				UpdatedAt:  timeNow,
+				UsageLimit: 5,
+				UsedTimes:  2,
+				Valid:      true,
+			},
+			expected: SetupKeyModel{
+				Id:                  types.StringValue("r2"),
+				Key:                 types.StringNull(),
+				Name:                types.StringValue("sk"),
+				State:               types.StringValue("active"),
+				Type:                types.StringValue("reusable"),
+				AllowExtraDnsLabels: types.BoolValue(false),
+				Ephemeral:           types.BoolValue(false),
+				Revoked:             types.BoolValue(false),
+				Valid:               types.BoolValue(true),
+				Expires:             types.StringValue(timeNow.Format(time.RFC3339)),
+				LastUsed:            types.StringValue(timeNow.Format(time.RFC3339)),
+				UpdatedAt:           types.StringValue(timeNow.Format(time.RFC3339)),
+				UsageLimit:          types.Int32Value(5),
+				UsedTimes:           types.Int32Value(2),
+				RemainingUses:       types.Int32Value(3),
+				AutoGroups:          types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+		},
+		{
+			// Unused key, expiry reported as zero time
+			resource: &api.SetupKey{
+				Id:         "r3",
+				AutoGroups: []string{},
+				LastUsed:   timeNow,
+				Name:       "sk",
+				State:      "valid",
+				Type:       "one-off",
+				UpdatedAt:  timeNow,
+				UsageLimit: 1,
+				UsedTimes:  0,
+				Valid:      true,
+			},
+			expected: SetupKeyModel{
+				Id:                  types.StringValue("r3"),
+				Key:                 types.StringNull(),
+				Name:                types.StringValue("sk"),
+				State:               types.StringValue("valid"),
+				Type:                types.StringValue("one-off"),
+				AllowExtraDnsLabels: types.BoolValue(false),
+				Ephemeral:           types.BoolValue(false),
+				Revoked:             types.BoolValue(false),
+				Valid:               types.BoolValue(true),
+				Expires:             types.StringNull(),
+				LastUsed:            types.StringNull(),
+				UpdatedAt:           types.StringValue(timeNow.Format(time.RFC3339)),
+				UsageLimit:          types.Int32Value(1),
+				UsedTimes:           types.Int32Value(0),
+				RemainingUses:       types.Int32Value(1),
+				AutoGroups:          types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+		},
+		{
+			// Unlimited expiry
+			resource: &api.SetupKey{
+				Id:         "r4",
+				AutoGroups: []string{},
+				Expires:    timeNow.AddDate(100, 0, 0),
+				LastUsed:   timeNow,
+				Name:       "sk",
+				State:      "valid",
+				Type:       "reusable",
+				UpdatedAt:  timeNow,
+				UsageLimit: 0,
+				UsedTimes:  3,
+				Valid:      true,
+			},
+			data: SetupKeyModel{
+				ExpirySeconds: types.Int32Value(0),
+				ExpiryDays:    types.Int32Null(),
+			},
+			expected: SetupKeyModel{
+				Id:                  types.StringValue("r4"),
+				Key:                 types.StringNull(),
+				Name:                types.StringValue("sk"),
+				State:               types.StringValue("valid"),
+				Type:                types.StringValue("reusable"),
+				AllowExtraDnsLabels: types.BoolValue(false),
+				Ephemeral:           types.BoolValue(false),
+				Revoked:             types.BoolValue(false),
+				Valid:               types.BoolValue(true),
+				Expires:             types.StringNull(),
+				LastUsed:            types.StringValue(timeNow.Format(time.RFC3339)),
+				UpdatedAt:           types.StringValue(timeNow.Format(time.RFC3339)),
+				UsageLimit:          types.Int32Value(0),
+				UsedTimes:           types.Int32Value(3),
+				RemainingUses:       types.Int32Null(),
+				ExpirySeconds:       types.Int32Value(0),
+				ExpiryDays:          types.Int32Null(),
+				AutoGroups:          types.ListValueMust(types.StringType, []attr.Value{}),
+			},
+		},
 	}
 
 	for _, c := range cases {
-		var out SetupKeyModel
+		out := c.data
 		outDiag := setupKeyAPIToTerraform(context.Background(), c.resource, &out)
 		if outDiag.HasError() {
 			t.Fatalf("Expected no error diagnostics, found %d errors", outDiag.ErrorsCount())
@@ -73,6 +191,115 @@ func Test_setupKeyAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_setupKeyExpiresIn(t *testing.T) {
+	cases := []struct {
+		data     SetupKeyModel
+		expected int
+	}{
+		{
+			data:     SetupKeyModel{ExpirySeconds: types.Int32Value(0), ExpiryDays: types.Int32Null()},
+			expected: 0,
+		},
+		{
+			data:     SetupKeyModel{ExpirySeconds: types.Int32Value(3600), ExpiryDays: types.Int32Null()},
+			expected: 3600,
+		},
+		{
+			data:     SetupKeyModel{ExpirySeconds: types.Int32Value(0), ExpiryDays: types.Int32Value(30)},
+			expected: 2592000,
+		},
+	}
+
+	for _, c := range cases {
+		if out := setupKeyExpiresIn(c.data); out != c.expected {
+			t.Fatalf("Expected ExpiresIn %d, found %d", c.expected, out)
+		}
+	}
+}
+
+func Test_setupKeyConfigValidator(t *testing.T) {
+	cases := []struct {
+		skType     types.String
+		usageLimit types.Int32
+		warnings   int
+	}{
+		{skType: types.StringValue("reusable"), usageLimit: types.Int32Null(), warnings: 1},
+		{skType: types.StringValue("reusable"), usageLimit: types.Int32Value(0), warnings: 1},
+		{skType: types.StringValue("reusable"), usageLimit: types.Int32Value(10), warnings: 0},
+		{skType: types.StringValue("reusable"), usageLimit: types.Int32Unknown(), warnings: 0},
+		{skType: types.StringValue("one-off"), usageLimit: types.Int32Null(), warnings: 0},
+		{skType: types.StringNull(), usageLimit: types.Int32Null(), warnings: 0},
+	}
+
+	r := &SetupKey{}
+	for _, c := range cases {
+		state := testResourceState(t, r, &SetupKeyModel{
+			Name:               types.StringValue("sk"),
+			Type:               c.skType,
+			UsageLimit:         c.usageLimit,
+			AutoGroups:         types.ListNull(types.StringType),
+			AutoGroupsResolved: types.ListNull(types.StringType),
+		})
+
+		resp := tfresource.ValidateConfigResponse{}
+		for _, v := range r.ConfigValidators(context.Background()) {
+			v.ValidateResource(context.Background(), tfresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if resp.Diagnostics.WarningsCount() != c.warnings {
+			t.Fatalf("Expected %d warnings for %s/%s, found %d", c.warnings, c.skType, c.usageLimit, resp.Diagnostics.WarningsCount())
+		}
+	}
+}
+
+func Test_setupKeyRevokedReplace(t *testing.T) {
+	cases := []struct {
+		state   bool
+		plan    types.Bool
+		replace bool
+	}{
+		{state: false, plan: types.BoolValue(true), replace: false},
+		{state: true, plan: types.BoolValue(true), replace: false},
+		{state: false, plan: types.BoolValue(false), replace: false},
+		{state: true, plan: types.BoolValue(false), replace: true},
+		{state: true, plan: types.BoolUnknown(), replace: false},
+	}
+
+	r := &SetupKey{}
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+	for _, c := range cases {
+		state := testResourceState(t, r, &SetupKeyModel{
+			Id:                 types.StringValue("sk1"),
+			Name:               types.StringValue("sk"),
+			Revoked:            types.BoolValue(c.state),
+			AutoGroups:         types.ListNull(types.StringType),
+			AutoGroupsResolved: types.ListNull(types.StringType),
+		})
+		req := planmodifier.BoolRequest{
+			Path:        path.Root("revoked"),
+			State:       state,
+			Plan:        tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+			StateValue:  types.BoolValue(c.state),
+			PlanValue:   c.plan,
+			ConfigValue: c.plan,
+		}
+
+		resp := planmodifier.BoolResponse{PlanValue: req.PlanValue}
+		for _, m := range schemaResp.Schema.Attributes["revoked"].(schema.BoolAttribute).PlanModifiers {
+			m.PlanModifyBool(context.Background(), req, &resp)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if resp.RequiresReplace != c.replace {
+			t.Fatalf("Expected replace=%t changing revoked from %t to %s, found %t", c.replace, c.state, c.plan, resp.RequiresReplace)
+		}
+	}
+}
+
 func Test_SetupKey_Create(t *testing.T) {
 	rName := "sk" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_setup_key." + rName
@@ -95,6 +322,7 @@ func Test_SetupKey_Create(t *testing.T) {
 					resource.TestCheckResourceAttr(rNameFull, "ephemeral", "false"),
 					resource.TestCheckResourceAttr(rNameFull, "revoked", "false"),
 					resource.TestCheckResourceAttr(rNameFull, "usage_limit", "0"),
+					resource.TestCheckNoResourceAttr(rNameFull, "remaining_uses"),
 					func(s *terraform.State) error {
 						pID := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
 						sk, err := testClient().SetupKeys.Get(context.Background(), pID)
@@ -217,6 +445,496 @@ func Test_SetupKey_Update_Revoke(t *testing.T) {
 	})
 }
 
+func Test_SetupKey_Read_keepsKey(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.SetupKey{Id: "sk1", Name: "sk", Key: "A6160****", Type: "one-off", AutoGroups: []string{}})
+	})
+
+	r := &SetupKey{client: client}
+	state := testResourceState(t, r, &SetupKeyModel{
+		Id:                 types.StringValue("sk1"),
+		Name:               types.StringValue("sk"),
+		Key:                types.StringValue("A616097E-FCF0-48FA-9354-CA4A61142761"),
+		AutoGroups:         types.ListNull(types.StringType),
+		AutoGroupsResolved: types.ListNull(types.StringType),
+	})
+	readResp := tfresource.ReadResponse{State: state}
+	r.Read(context.Background(), tfresource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", readResp.Diagnostics.Errors())
+	}
+
+	var key types.String
+	readResp.Diagnostics.Append(readResp.State.GetAttribute(context.Background(), path.Root("key"), &key)...)
+	if key.ValueString() != "A616097E-FCF0-48FA-9354-CA4A61142761" {
+		t.Fatalf("Expected key to be kept from state, found %s", key)
+	}
+
+	importResp := tfresource.ImportStateResponse{State: tfsdk.State{Schema: state.Schema, Raw: tftypes.NewValue(state.Schema.Type().TerraformType(context.Background()), nil)}}
+	r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: "sk1"}, &importResp)
+	if importResp.Diagnostics.HasError() || importResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning on import, found %v", importResp.Diagnostics)
+	}
+	importResp.Diagnostics.Append(importResp.State.GetAttribute(context.Background(), path.Root("key"), &key)...)
+	if !key.IsNull() {
+		t.Fatalf("Expected imported key to be null, found %s", key)
+	}
+}
+
+func Test_SetupKey_Read_autoGroupsResolved(t *testing.T) {
+	cases := []struct {
+		autoGroups    []string
+		resolve       bool
+		expected      types.List
+		expectedLists int
+	}{
+		{autoGroups: []string{"g2", "g1"}, resolve: true, expected: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Devs"), types.StringValue("Admins")}), expectedLists: 1},
+		{autoGroups: []string{"g1", "unknown"}, resolve: true, expected: types.ListNull(types.StringType), expectedLists: 1},
+		{autoGroups: []string{}, resolve: true, expected: types.ListValueMust(types.StringType, []attr.Value{})},
+		{autoGroups: []string{"g2", "g1"}, resolve: false, expected: types.ListNull(types.StringType)},
+	}
+
+	for _, c := range cases {
+		lists := 0
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/api/groups" {
+				lists++
+				_, _ = w.Write([]byte(`[{"id":"g1","name":"Admins","peers":[],"resources":[]},{"id":"g2","name":"Devs","peers":[],"resources":[]}]`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(api.SetupKey{Id: "sk1", Name: "sk", Type: "reusable", AutoGroups: c.autoGroups})
+		})
+
+		r := &SetupKey{client: client}
+		state := testResourceState(t, r, &SetupKeyModel{
+			Id:                 types.StringValue("sk1"),
+			Name:               types.StringValue("sk"),
+			AutoGroups:         types.ListNull(types.StringType),
+			AutoGroupsResolved: types.ListNull(types.StringType),
+			ResolveAutoGroups:  types.BoolValue(c.resolve),
+		})
+		readResp := tfresource.ReadResponse{State: state}
+		r.Read(context.Background(), tfresource.ReadRequest{State: state}, &readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", readResp.Diagnostics.Errors())
+		}
+
+		var resolved types.List
+		readResp.Diagnostics.Append(readResp.State.GetAttribute(context.Background(), path.Root("auto_groups_resolved"), &resolved)...)
+		if !resolved.Equal(c.expected) {
+			t.Fatalf("Expected auto_groups_resolved %s for %v, found %s", c.expected, c.autoGroups, resolved)
+		}
+		if lists != c.expectedLists {
+			t.Fatalf("Expected %d group list requests with resolve_auto_groups %t, found %d", c.expectedLists, c.resolve, lists)
+		}
+	}
+}
+
+func Test_SetupKey_autoGroupsResolvedPlan(t *testing.T) {
+	resolved := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Admins")})
+	cases := []struct {
+		autoGroups []string
+		resolve    bool
+		unknown    bool
+	}{
+		{autoGroups: []string{"g1"}, resolve: true, unknown: false},
+		{autoGroups: []string{"g2"}, resolve: true, unknown: true},
+		{autoGroups: []string{"g1"}, resolve: false, unknown: true},
+	}
+
+	r := &SetupKey{}
+	state := testResourceState(t, r, &SetupKeyModel{
+		Id:                 types.StringValue("sk1"),
+		Name:               types.StringValue("sk"),
+		AutoGroups:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+		AutoGroupsResolved: resolved,
+		ResolveAutoGroups:  types.BoolValue(true),
+	})
+	for _, c := range cases {
+		plan := testResourceState(t, r, &SetupKeyModel{
+			Id:                 types.StringValue("sk1"),
+			Name:               types.StringValue("sk"),
+			AutoGroups:         types.ListValueMust(types.StringType, toStringValues(c.autoGroups)),
+			AutoGroupsResolved: resolved,
+			ResolveAutoGroups:  types.BoolValue(c.resolve),
+		})
+		req := planmodifier.ListRequest{
+			Path:       path.Root("auto_groups_resolved"),
+			State:      state,
+			Plan:       tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+			StateValue: resolved,
+			PlanValue:  resolved,
+		}
+		resp := planmodifier.ListResponse{PlanValue: req.PlanValue}
+		setupKeyAutoGroupsResolvedPlanModifier{}.PlanModifyList(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if resp.PlanValue.IsUnknown() != c.unknown {
+			t.Fatalf("Expected unknown=%t for auto_groups %v and resolve_auto_groups %t, found %s", c.unknown, c.autoGroups, c.resolve, resp.PlanValue)
+		}
+	}
+}
+
+func Test_SetupKey_groupsListError(t *testing.T) {
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/groups":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"internal error","code":500}`))
+		case r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(api.SetupKeyClear{Id: "sk1", Name: "sk", Key: "plaintext", Type: "reusable", AutoGroups: []string{"g1"}})
+		default:
+			_ = json.NewEncoder(w).Encode(api.SetupKey{Id: "sk1", Name: "sk", Type: "reusable", AutoGroups: []string{"g1"}})
+		}
+	})
+
+	r := &SetupKey{client: client}
+	plan := testResourceState(t, r, &SetupKeyModel{
+		Name:               types.StringValue("sk"),
+		Type:               types.StringValue("reusable"),
+		AutoGroups:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}),
+		AutoGroupsResolved: types.ListUnknown(types.StringType),
+		ResolveAutoGroups:  types.BoolValue(true),
+	})
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Error listing Groups" {
+		t.Fatalf("Expected Error listing Groups warning, found %v", resp.Diagnostics.Warnings())
+	}
+
+	// The created key is kept in state with its plaintext key
+	var created SetupKeyModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &created)...)
+	if created.Id.ValueString() != "sk1" || created.Key.ValueString() != "plaintext" || !created.AutoGroupsResolved.IsNull() {
+		t.Fatalf("Expected created key sk1 with its key and null auto_groups_resolved in state, found %s %s %s", created.Id, created.Key, created.AutoGroupsResolved)
+	}
+
+	readResp := tfresource.ReadResponse{State: resp.State}
+	r.Read(context.Background(), tfresource.ReadRequest{State: resp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", readResp.Diagnostics.Errors())
+	}
+	if readResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected 1 warning, found %v", readResp.Diagnostics.Warnings())
+	}
+}
+
+func Test_SetupKey_Create_defaultAutoGroups(t *testing.T) {
+	cases := []struct {
+		name       string
+		autoGroups types.List
+		expected   []string
+	}{
+		{name: "not configured", autoGroups: types.ListUnknown(types.StringType), expected: []string{"g-default"}},
+		{name: "configured", autoGroups: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1")}), expected: []string{"g1"}},
+		{name: "configured empty", autoGroups: types.ListValueMust(types.StringType, []attr.Value{}), expected: []string{}},
+	}
+
+	for _, c := range cases {
+		var received api.CreateSetupKeyRequest
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/api/groups" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(api.SetupKeyClear{Id: "sk1", Name: received.Name, Key: "key", Type: received.Type, AutoGroups: received.AutoGroups})
+		})
+
+		r := &SetupKey{client: client, defaultAutoGroups: []string{"g-default"}}
+		state := testResourceState(t, r, &SetupKeyModel{
+			Name:               types.StringValue("sk"),
+			Type:               types.StringValue("reusable"),
+			AutoGroups:         c.autoGroups,
+			AutoGroupsResolved: types.ListUnknown(types.StringType),
+		})
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: state.Schema}}
+		r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics for %s, found %v", c.name, resp.Diagnostics.Errors())
+		}
+		if !reflect.DeepEqual(received.AutoGroups, c.expected) {
+			t.Fatalf("Expected auto_groups %v in create request for %s, found %v", c.expected, c.name, received.AutoGroups)
+		}
+	}
+}
+
+func Test_SetupKey_Create_nameTemplate(t *testing.T) {
+	cases := []struct {
+		template string
+		expected string
+	}{
+		{template: "", expected: "web"},
+		{template: "env-{{.Name}}", expected: "env-web"},
+	}
+
+	for _, c := range cases {
+		var received api.CreateSetupKeyRequest
+		client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/api/groups":
+				_, _ = w.Write([]byte(`[]`))
+			case r.Method == http.MethodPost:
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Fatalf("Failed to decode request: %v", err)
+				}
+				_ = json.NewEncoder(w).Encode(api.SetupKeyClear{Id: "sk1", Name: received.Name, Key: "key", Type: received.Type, AutoGroups: []string{}})
+			default:
+				_ = json.NewEncoder(w).Encode(api.SetupKey{Id: "sk1", Name: received.Name, Type: received.Type, AutoGroups: []string{}})
+			}
+		})
+
+		tmpl, err := parseSetupKeyNameTemplate(c.template)
+		if err != nil {
+			t.Fatalf("Expected template %q to parse, found %v", c.template, err)
+		}
+		r := &SetupKey{client: client, nameTemplate: tmpl}
+		state := testResourceState(t, r, &SetupKeyModel{
+			Name:               types.StringValue("web"),
+			Type:               types.StringValue("reusable"),
+			AutoGroups:         types.ListValueMust(types.StringType, []attr.Value{}),
+			AutoGroupsResolved: types.ListUnknown(types.StringType),
+		})
+		resp := tfresource.CreateResponse{State: tfsdk.State{Schema: state.Schema}}
+		r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if received.Name != c.expected {
+			t.Fatalf("Expected name %q in create request with template %q, found %q", c.expected, c.template, received.Name)
+		}
+
+		readResp := tfresource.ReadResponse{State: resp.State}
+		r.Read(context.Background(), tfresource.ReadRequest{State: resp.State}, &readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", readResp.Diagnostics.Errors())
+		}
+		var name types.String
+		readResp.Diagnostics.Append(readResp.State.GetAttribute(context.Background(), path.Root("name"), &name)...)
+		if name.ValueString() != "web" {
+			t.Fatalf("Expected configured name web in state with template %q, found %s", c.template, name)
+		}
+		var serverName types.String
+		readResp.Diagnostics.Append(readResp.State.GetAttribute(context.Background(), path.Root("server_name"), &serverName)...)
+		if serverName.ValueString() != c.expected {
+			t.Fatalf("Expected server name %q in state with template %q, found %s", c.expected, c.template, serverName)
+		}
+	}
+}
+
+func Test_SetupKey_ModifyPlan_name(t *testing.T) {
+	cases := []struct {
+		template   string
+		stateName  string
+		serverName string
+		planName   string
+		replace    bool
+	}{
+		// The template changed after creating the setup key
+		{template: "prod-{{.Name}}", stateName: "web", serverName: "env-web", planName: "web"},
+		{template: "", stateName: "web", serverName: "env-web", planName: "web"},
+		// Imported setup keys use the name on the server
+		{template: "env-{{.Name}}", stateName: "env-web", serverName: "env-web", planName: "web"},
+		{template: "", stateName: "env-web", serverName: "env-web", planName: "web", replace: true},
+		{template: "env-{{.Name}}", stateName: "web", serverName: "env-web", planName: "api", replace: true},
+	}
+
+	for _, c := range cases {
+		tmpl, err := parseSetupKeyNameTemplate(c.template)
+		if err != nil {
+			t.Fatalf("Expected template %q to parse, found %v", c.template, err)
+		}
+		r := &SetupKey{nameTemplate: tmpl}
+		state := testResourceState(t, r, &SetupKeyModel{
+			Id:                 types.StringValue("sk1"),
+			Name:               types.StringValue(c.stateName),
+			ServerName:         types.StringValue(c.serverName),
+			AutoGroups:         types.ListValueMust(types.StringType, []attr.Value{}),
+			AutoGroupsResolved: types.ListNull(types.StringType),
+		})
+		plan := testResourceState(t, r, &SetupKeyModel{
+			Id:                 types.StringValue("sk1"),
+			Name:               types.StringValue(c.planName),
+			ServerName:         types.StringValue(c.serverName),
+			AutoGroups:         types.ListValueMust(types.StringType, []attr.Value{}),
+			AutoGroupsResolved: types.ListNull(types.StringType),
+		})
+		resp := tfresource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}
+		r.ModifyPlan(context.Background(), tfresource.ModifyPlanRequest{State: state, Plan: resp.Plan}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if (len(resp.RequiresReplace) > 0) != c.replace {
+			t.Fatalf("Expected replace=%t renaming %s (%s) to %s with template %q, found %v", c.replace, c.stateName, c.serverName, c.planName, c.template, resp.RequiresReplace)
+		}
+	}
+}
+
+func Test_SetupKey_KeyPersisted(t *testing.T) {
+	rName := "sk" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_setup_key." + rName
+	var key string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSetupKeyResource(rName, `3600`, `reusable`, `false`, `[]`, `false`, `false`, `10`),
+				Check: resource.TestCheckResourceAttrWith(rNameFull, "key", func(value string) error {
+					key = value
+					return nil
+				}),
+			},
+			{
+				Config: testSetupKeyResource(rName, `3600`, `reusable`, `false`, `[]`, `false`, `false`, `10`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: resource.TestCheckResourceAttrWith(rNameFull, "key", func(value string) error {
+					if value != key {
+						return fmt.Errorf("Expected key to be unchanged after second apply")
+					}
+					return nil
+				}),
+			},
+			{
+				ResourceName:            rNameFull,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+		},
+	})
+}
+
+func Test_SetupKey_ExpiryDays(t *testing.T) {
+	rName := "sk" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_setup_key." + rName
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`resource "netbird_setup_key" "%s" {
+  name           = "%s"
+  expiry_days    = 30
+  expiry_seconds = 3600
+}`, rName, rName),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				ResourceName: rName,
+				Config: fmt.Sprintf(`resource "netbird_setup_key" "%s" {
+  name        = "%s"
+  expiry_days = 30
+}`, rName, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(rNameFull, "id"),
+					resource.TestCheckResourceAttr(rNameFull, "expiry_days", "30"),
+					func(s *terraform.State) error {
+						pID := s.RootModule().Resources[rNameFull].Primary.Attributes["id"]
+						sk, err := testClient().SetupKeys.Get(context.Background(), pID)
+						if err != nil {
+							return err
+						}
+						expiresIn := time.Until(sk.Expires)
+						if expiresIn < 29*24*time.Hour || expiresIn > 30*24*time.Hour {
+							return fmt.Errorf("Mismatch, expected setup key to expire in 30 days, found %s", expiresIn)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func Test_SetupKeyDataSource_Read_name(t *testing.T) {
+	cases := []struct {
+		name          string
+		expectedId    string
+		expectedError string
+	}{
+		{name: "deploy", expectedId: "sk1"},
+		{name: "ci", expectedError: "Multiple Matches"},
+		{name: "missing", expectedError: "No match"},
+	}
+
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id":"sk1","name":"deploy","key":"A616****","type":"reusable","state":"valid","valid":true,"auto_groups":[],"expires":"2030-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","last_used":"0001-01-01T00:00:00Z"},
+			{"id":"sk2","name":"ci","key":"B616****","type":"one-off","state":"overused","valid":false,"auto_groups":[],"expires":"2030-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","last_used":"0001-01-01T00:00:00Z"},
+			{"id":"sk3","name":"ci","key":"C616****","type":"one-off","state":"valid","valid":true,"auto_groups":[],"expires":"2030-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","last_used":"0001-01-01T00:00:00Z"}
+		]`))
+	})
+
+	for _, c := range cases {
+		d := &SetupKeyDataSource{client: client}
+		req, resp := testDataSourceRead(t, d, &SetupKeyDataSourceModel{
+			Name:       types.StringValue(c.name),
+			AutoGroups: types.ListNull(types.StringType),
+		})
+		d.Read(context.Background(), req, resp)
+		if c.expectedError != "" {
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != c.expectedError {
+				t.Fatalf("Expected %s error reading %s, found %v", c.expectedError, c.name, resp.Diagnostics.Errors())
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+
+		var out SetupKeyDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &out)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Expected no error diagnostics, found %v", resp.Diagnostics.Errors())
+		}
+		if out.Id.ValueString() != c.expectedId || !out.Valid.ValueBool() || out.State.ValueString() != "valid" {
+			t.Fatalf("Expected valid setup key %s, found %s (valid %s, state %s)", c.expectedId, out.Id, out.Valid, out.State)
+		}
+	}
+}
+
+func Test_SetupKey_DataSource(t *testing.T) {
+	rName := "sk" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	rNameFull := "netbird_setup_key." + rName
+	dsNameFull := "data.netbird_setup_key." + rName
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testEnsureManagementRunning(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSetupKeyResource(rName, `1800`, `reusable`, `false`, `[]`, `false`, `false`, `0`) + fmt.Sprintf(`
+data "netbird_setup_key" "%s" {
+  name = netbird_setup_key.%s.name
+}
+`, rName, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dsNameFull, "id", rNameFull, "id"),
+					resource.TestCheckResourceAttr(dsNameFull, "name", rName),
+					resource.TestCheckResourceAttr(dsNameFull, "type", "reusable"),
+					resource.TestCheckResourceAttr(dsNameFull, "valid", "true"),
+					resource.TestCheckResourceAttr(dsNameFull, "state", "valid"),
+					resource.TestCheckNoResourceAttr(dsNameFull, "key"),
+				),
+			},
+		},
+	})
+}
+
 func testSetupKeyResource(rName, expiry, skType, allowExtraDNS, groups, ephemeral, revoked, usageLimit string) string {
 	return fmt.Sprintf(`resource "netbird_setup_key" "%s" {
   name                   = "%s"
@@ -81,18 +81,18 @@ func (d *TokenDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 func tokenDataSourceAPIToTerraform(token *api.PersonalAccessToken, data *TokenDataSourceModel) {
@@ -125,7 +125,7 @@ func (d *TokenDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	tokens, err := d.client.Tokens.List(ctx, data.UserID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Tokens", err.Error())
+		resp.Diagnostics.AddError("Error listing Tokens", formatAPIError(err))
 		return
 	}
 
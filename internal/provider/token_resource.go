@@ -78,9 +78,10 @@ Personal Access Tokens can only be created for current user if the current user
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			"expiration_days": schema.Int32Attribute{
-				Required:      true,
-				PlanModifiers: []planmodifier.Int32{int32planmodifier.RequiresReplace()},
-				Validators:    []validator.Int32{int32validator.Between(1, 365)},
+				Required:            true,
+				MarkdownDescription: "Token expiration in days (1-365)",
+				PlanModifiers:       []planmodifier.Int32{int32planmodifier.RequiresReplace()},
+				Validators:          []validator.Int32{int32validator.Between(1, 365)},
 			},
 			"user_id": schema.StringAttribute{
 				Required:            true,
@@ -100,7 +101,7 @@ Personal Access Tokens can only be created for current user if the current user
 			"token": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
-				MarkdownDescription: "Plaintext token",
+				MarkdownDescription: "Plaintext token, only returned by the API when the token is created, null for imported tokens",
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 		},
@@ -113,18 +114,18 @@ func (r *Token) Configure(ctx context.Context, req resource.ConfigureRequest, re
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func tokenAPIToTerraform(token *api.PersonalAccessToken, data *TokenModel) {
@@ -156,7 +157,7 @@ func (r *Token) Create(ctx context.Context, req resource.CreateRequest, resp *re
 
 	token, err := r.client.Tokens.Create(ctx, data.UserID.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Token", err.Error())
+		resp.Diagnostics.AddError("Error creating Token", formatAPIError(err))
 		return
 	}
 
@@ -188,7 +189,7 @@ func (r *Token) Read(ctx context.Context, req resource.ReadRequest, resp *resour
 			resp.State.RemoveResource(ctx)
 			return
 		} else {
-			resp.Diagnostics.AddError("Error getting Token", err.Error())
+			resp.Diagnostics.AddError("Error getting Token", formatAPIError(err))
 		}
 		return
 	}
@@ -226,8 +227,8 @@ func (r *Token) Delete(ctx context.Context, req resource.DeleteRequest, resp *re
 	}
 
 	err := r.client.Tokens.Delete(ctx, data.UserID.ValueString(), data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting Token", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting Token", formatAPIError(err))
 	}
 }
 
@@ -239,4 +240,5 @@ func (r *Token) ImportState(ctx context.Context, req resource.ImportStateRequest
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), splitID[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), splitID[1])...)
+	resp.Diagnostics.AddAttributeWarning(path.Root("token"), "Token Not Imported", "The plaintext token is only returned when the token is created, token is null for imported tokens.")
 }
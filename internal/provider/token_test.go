@@ -2,12 +2,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -50,6 +56,52 @@ func Test_tokenAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_Token_plainTokenOnCreate(t *testing.T) {
+	pat := api.PersonalAccessToken{Id: "t1", Name: "ci", CreatedAt: time.Now(), ExpirationDate: time.Now().Add(24 * time.Hour)}
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			_ = json.NewEncoder(w).Encode(api.PersonalAccessTokenGenerated{PersonalAccessToken: pat, PlainToken: "nbp_plaintext"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pat)
+	})
+
+	r := &Token{client: client}
+	plan := testResourceState(t, r, &TokenModel{
+		Name:           types.StringValue("ci"),
+		UserID:         types.StringValue("u1"),
+		ExpirationDays: types.Int32Value(1),
+	})
+	createResp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", createResp.Diagnostics.Errors())
+	}
+
+	readResp := tfresource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), tfresource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error diagnostics, found %v", readResp.Diagnostics.Errors())
+	}
+
+	var token types.String
+	readResp.Diagnostics.Append(readResp.State.GetAttribute(context.Background(), path.Root("token"), &token)...)
+	if token.ValueString() != "nbp_plaintext" {
+		t.Fatalf("Expected plaintext token to be kept after read, found %s", token)
+	}
+
+	importResp := tfresource.ImportStateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+	r.ImportState(context.Background(), tfresource.ImportStateRequest{ID: "u1/t1"}, &importResp)
+	if importResp.Diagnostics.HasError() || importResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning on import, found %v", importResp.Diagnostics)
+	}
+	importResp.Diagnostics.Append(importResp.State.GetAttribute(context.Background(), path.Root("token"), &token)...)
+	if !token.IsNull() {
+		t.Fatalf("Expected imported token to be null, found %s", token)
+	}
+}
+
 func Test_Token_Create(t *testing.T) {
 	rName := "t" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_token." + rName
@@ -80,6 +132,14 @@ func Test_Token_Create(t *testing.T) {
 					},
 				),
 			},
+			{
+				ResourceName:      rNameFull,
+				ImportState:       true,
+				ImportStateIdFunc: testTokenImportStateIdFunc(rNameFull),
+				ImportStateVerify: true,
+				// The plaintext token is only returned on creation
+				ImportStateVerifyIgnore: []string{"token"},
+			},
 		},
 	})
 }
@@ -92,3 +152,13 @@ func testTokenResource(rName, userID, expiryDays string) string {
 }
 `, rName, userID, rName, expiryDays)
 }
+
+func testTokenImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+		return rs.Primary.Attributes["user_id"] + "/" + rs.Primary.ID, nil
+	}
+}
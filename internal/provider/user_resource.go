@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
@@ -55,6 +56,12 @@ type UserModel struct {
 	IsBlocked     types.Bool   `tfsdk:"is_blocked"`
 }
 
+// UserResourceModel describes the resource data model.
+type UserResourceModel struct {
+	UserModel
+	ValidateGroups types.Bool `tfsdk:"validate_groups"`
+}
+
 func (r *User) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user"
 }
@@ -129,6 +136,12 @@ func (r *User) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 				Computed:            true,
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
+			"validate_groups": schema.BoolAttribute{
+				MarkdownDescription: "Check that auto_groups exist before creating or updating the user, requires an additional API call",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -139,18 +152,18 @@ func (r *User) Configure(ctx context.Context, req resource.ConfigureRequest, res
 		return
 	}
 
-	client, ok := req.ProviderData.(*netbird.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *netbird.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func userAPIToTerraform(ctx context.Context, user *api.User, data *UserModel) diag.Diagnostics {
@@ -172,7 +185,7 @@ func userAPIToTerraform(ctx context.Context, user *api.User, data *UserModel) di
 }
 
 func (r *User) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data UserModel
+	var data UserResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -181,6 +194,13 @@ func (r *User) Create(ctx context.Context, req resource.CreateRequest, resp *res
 		return
 	}
 
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, stringListDefault(ctx, data.AutoGroups, []string{}))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	userReq := api.UserCreateRequest{
 		AutoGroups:    stringListDefault(ctx, data.AutoGroups, []string{}),
 		IsServiceUser: data.IsServiceUser.ValueBool(),
@@ -191,11 +211,11 @@ func (r *User) Create(ctx context.Context, req resource.CreateRequest, resp *res
 
 	user, err := r.client.Users.Create(ctx, userReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating user", err.Error())
+		resp.Diagnostics.AddError("Error creating user", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(userAPIToTerraform(ctx, user, &data)...)
+	resp.Diagnostics.Append(userAPIToTerraform(ctx, user, &data.UserModel)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -206,7 +226,7 @@ func (r *User) Create(ctx context.Context, req resource.CreateRequest, resp *res
 }
 
 func (r *User) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data UserModel
+	var data UserResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -217,12 +237,12 @@ func (r *User) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 
 	users, err := r.client.Users.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing users", err.Error())
+		resp.Diagnostics.AddError("Error listing users", formatAPIError(err))
 		return
 	}
 	for _, u := range users {
 		if u.Id == data.Id.ValueString() {
-			resp.Diagnostics.Append(userAPIToTerraform(ctx, &u, &data)...)
+			resp.Diagnostics.Append(userAPIToTerraform(ctx, &u, &data.UserModel)...)
 
 			if resp.Diagnostics.HasError() {
 				return
@@ -238,7 +258,7 @@ func (r *User) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 }
 
 func (r *User) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data UserModel
+	var data UserResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -252,6 +272,13 @@ func (r *User) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 		return
 	}
 
+	if data.ValidateGroups.ValueBool() {
+		resp.Diagnostics.Append(validateGroupIDs(ctx, r.client, stringListDefault(ctx, data.AutoGroups, []string{}))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	user, err := r.client.Users.Update(ctx, data.Id.ValueString(), api.UserRequest{
 		AutoGroups: stringListDefault(ctx, data.AutoGroups, []string{}),
 		IsBlocked:  data.IsBlocked.ValueBool(),
@@ -259,11 +286,11 @@ func (r *User) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating User", err.Error())
+		resp.Diagnostics.AddError("Error updating User", formatAPIError(err))
 		return
 	}
 
-	resp.Diagnostics.Append(userAPIToTerraform(ctx, user, &data)...)
+	resp.Diagnostics.Append(userAPIToTerraform(ctx, user, &data.UserModel)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -281,7 +308,7 @@ func (r *User) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 }
 
 func (r *User) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data UserModel
+	var data UserResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -291,11 +318,12 @@ func (r *User) Delete(ctx context.Context, req resource.DeleteRequest, resp *res
 	}
 
 	err := r.client.Users.Delete(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting User", err.Error())
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting User", formatAPIError(err))
 	}
 }
 
 func (r *User) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("validate_groups"), false)...)
 }
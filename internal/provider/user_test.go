@@ -3,11 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -65,6 +69,39 @@ func Test_userAPIToTerraform(t *testing.T) {
 	}
 }
 
+func Test_User_Create_validateGroups(t *testing.T) {
+	creates := 0
+	client := testStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			creates++
+		}
+		_, _ = w.Write([]byte(`[{"id":"g1","name":"Existing","peers":[],"resources":[]}]`))
+	})
+
+	r := &User{client: client}
+	plan := testResourceState(t, r, &UserResourceModel{
+		UserModel: UserModel{
+			Role:          types.StringValue("user"),
+			IsServiceUser: types.BoolValue(true),
+			AutoGroups:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("g1"), types.StringValue("nonexistent")}),
+		},
+		ValidateGroups: types.BoolValue(true),
+	})
+	resp := tfresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), tfresource.CreateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Group Not Found" {
+		t.Fatalf("Expected Group Not Found error, found %v", resp.Diagnostics.Errors())
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, `"nonexistent"`) || strings.Contains(detail, `"g1"`) {
+		t.Fatalf("Expected error to name only the missing group, found %s", detail)
+	}
+	if creates != 0 {
+		t.Fatalf("Expected no create request, found %d", creates)
+	}
+}
+
 func Test_User_Create(t *testing.T) {
 	rName := "u" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	rNameFull := "netbird_user." + rName
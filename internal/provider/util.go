@@ -4,11 +4,17 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
 	"slices"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
 )
 
 func boolDefault(a types.Bool, b bool) bool {
@@ -32,6 +38,14 @@ func stringDefaultPointer(a types.String, b *string) *string {
 	return a.ValueStringPointer()
 }
 
+// stringNonEmptyPointer returns nil for null, unknown and empty strings.
+func stringNonEmptyPointer(a types.String) *string {
+	if a.IsUnknown() || a.IsNull() || a.ValueString() == "" {
+		return nil
+	}
+	return a.ValueStringPointer()
+}
+
 func stringListDefault(ctx context.Context, a types.List, b []string) []string {
 	if a.IsUnknown() || a.IsNull() {
 		return b
@@ -114,6 +128,42 @@ func matchListString(ctx context.Context, a []string, b types.List) (int, diag.D
 	return 1, d
 }
 
+// isNotFound reports whether err is a 404 response from the management API, other errors mentioning
+// a missing object, e.g. a referenced group, are not treated as the object itself being gone.
+func isNotFound(err error) bool {
+	return netbird.IsNotFound(err)
+}
+
+// formatAPIError describes err for diagnostics, management API errors are suffixed with their HTTP status
+// as the message returned by the API can be terse or empty.
+func formatAPIError(err error) string {
+	var apiErr *netbird.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	status := fmt.Sprintf("HTTP %d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	if err.Error() == "" {
+		return status
+	}
+	return fmt.Sprintf("%s (%s)", err.Error(), status)
+}
+
+// normalizeDescription maps missing descriptions to an empty string, matching the schema default of description attributes.
+func normalizeDescription(description *string) types.String {
+	if description == nil {
+		return types.StringValue("")
+	}
+	return types.StringValue(*description)
+}
+
+// timeStringOrNull formats t as RFC3339, the zero time the API returns for unset timestamps maps to null.
+func timeStringOrNull(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
+
 func knownCount(vals ...attr.Value) int {
 	ret := 0
 	for _, v := range vals {
@@ -123,3 +173,22 @@ func knownCount(vals ...attr.Value) int {
 	}
 	return ret
 }
+
+// pollUntil calls fn until it reports done or fails, waiting between calls with exponential backoff starting
+// at interval and capped at maxInterval. Each wait is jittered between half and all of the current backoff.
+func pollUntil(ctx context.Context, interval, maxInterval time.Duration, fn func() (bool, error)) error {
+	for {
+		done, err := fn()
+		if err != nil || done {
+			return err
+		}
+
+		wait := interval/2 + rand.N(interval/2+1)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
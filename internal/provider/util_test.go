@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	netbird "github.com/netbirdio/netbird/shared/management/client/rest"
+	"github.com/netbirdio/netbird/shared/management/http/api"
+)
+
+func Test_isNotFound(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: &netbird.APIError{StatusCode: http.StatusNotFound, Message: "peer does not exist"}, expected: true},
+		{err: fmt.Errorf("deleting: %w", &netbird.APIError{StatusCode: http.StatusNotFound}), expected: true},
+		{err: &netbird.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "group not found"}, expected: false},
+		{err: &netbird.APIError{StatusCode: http.StatusForbidden, Message: "permission denied"}, expected: false},
+	}
+
+	for _, c := range cases {
+		if isNotFound(c.err) != c.expected {
+			t.Fatalf("Expected isNotFound(%v) to be %t", c.err, c.expected)
+		}
+	}
+}
+
+func Test_formatAPIError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{err: &netbird.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "invalid port range"}, expected: "invalid port range (HTTP 422 Unprocessable Entity)"},
+		{err: &netbird.APIError{StatusCode: http.StatusBadGateway}, expected: "HTTP 502 Bad Gateway"},
+		{err: fmt.Errorf("creating: %w", &netbird.APIError{StatusCode: http.StatusConflict, Message: "name taken"}), expected: "creating: name taken (HTTP 409 Conflict)"},
+		{err: errors.New("connection refused"), expected: "connection refused"},
+	}
+
+	for _, c := range cases {
+		if out := formatAPIError(c.err); out != c.expected {
+			t.Fatalf("Expected %q, found %q", c.expected, out)
+		}
+	}
+}
+
+func Test_normalizeDescription(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource tfresource.Resource
+		read     func(description *string) (types.String, diag.Diagnostics)
+	}{
+		{
+			name:     "policy",
+			resource: &Policy{},
+			read: func(description *string) (types.String, diag.Diagnostics) {
+				var data PolicyModel
+				d := policyAPIToTerraform(context.Background(), &api.Policy{Id: valPtr("p1"), Description: description}, &data)
+				return data.Description, d
+			},
+		},
+		{
+			name:     "posture check",
+			resource: &PostureCheck{},
+			read: func(description *string) (types.String, diag.Diagnostics) {
+				var data PostureCheckModel
+				d := postureCheckAPIToTerraform(context.Background(), &api.PostureCheck{Description: description}, &data)
+				return data.Description, d
+			},
+		},
+		{
+			name:     "route",
+			resource: &Route{},
+			read: func(description *string) (types.String, diag.Diagnostics) {
+				var data RouteModel
+				route := api.Route{}
+				if description != nil {
+					route.Description = *description
+				}
+				d := routeAPIToTerraform(context.Background(), &route, &data)
+				return data.Description, d
+			},
+		},
+		{
+			name:     "network resource",
+			resource: &NetworkResource{},
+			read: func(description *string) (types.String, diag.Diagnostics) {
+				var data NetworkResourceModel
+				d := networkResourceAPIToTerraform(context.Background(), &api.NetworkResource{Description: description}, &data)
+				return data.Description, d
+			},
+		},
+		{
+			name:     "nameserver group",
+			resource: &NameserverGroup{},
+			read: func(description *string) (types.String, diag.Diagnostics) {
+				var data NameserverGroupModel
+				nameserverGroup := api.NameserverGroup{}
+				if description != nil {
+					nameserverGroup.Description = *description
+				}
+				d := nameserverGroupAPIToTerraform(context.Background(), &nameserverGroup, &data)
+				return data.Description, d
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schemaResp := tfresource.SchemaResponse{}
+			c.resource.Schema(context.Background(), tfresource.SchemaRequest{}, &schemaResp)
+			attribute, ok := schemaResp.Schema.Attributes["description"].(schema.StringAttribute)
+			if !ok || attribute.Default == nil {
+				t.Fatalf("Expected description to be a string attribute with a default")
+			}
+			defaultResp := defaults.StringResponse{}
+			attribute.Default.DefaultString(context.Background(), defaults.StringRequest{}, &defaultResp)
+
+			// An omitted description plans the default, reading it back must not produce a diff
+			for _, description := range []*string{nil, valPtr("")} {
+				out, d := c.read(description)
+				if d.HasError() {
+					t.Fatalf("Expected no error diagnostics, found %v", d.Errors())
+				}
+				if !out.Equal(defaultResp.PlanValue) {
+					t.Fatalf("Expected description %s, found %s", defaultResp.PlanValue, out)
+				}
+			}
+		})
+	}
+}
+
+func Test_pollUntil(t *testing.T) {
+	t.Run("stops on success", func(t *testing.T) {
+		calls := 0
+		err := pollUntil(context.Background(), time.Millisecond, 4*time.Millisecond, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, found %v", err)
+		}
+		if calls != 3 {
+			t.Fatalf("Expected 3 calls, found %d", calls)
+		}
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		calls := 0
+		err := pollUntil(context.Background(), time.Millisecond, 4*time.Millisecond, func() (bool, error) {
+			calls++
+			return false, errors.New("failed")
+		})
+		if err == nil || calls != 1 {
+			t.Fatalf("Expected error after 1 call, found %v after %d calls", err, calls)
+		}
+	})
+
+	t.Run("stops on context cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := pollUntil(ctx, time.Hour, time.Hour, func() (bool, error) {
+			calls++
+			cancel()
+			return false, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, found %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("Expected 1 call, found %d", calls)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = cidrValidator{}
+
+// cidrValidator validates that a string is an IPv4 or IPv6 range in CIDR notation,
+// non-canonical ranges are accepted and compared by their canonical form.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(ctx context.Context) string {
+	return "value must be a valid IPv4 or IPv6 range in CIDR notation"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := netip.ParsePrefix(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR Range", fmt.Sprintf("%q is not a valid CIDR range, %s", value, err.Error()))
+	}
+}
+
+var _ validator.String = minVersionValidator{}
+
+// minVersionValidator validates that a minimum version is a semantic version and warns about pre-releases,
+// as pre-releases sort before their final release.
+type minVersionValidator struct{}
+
+func (v minVersionValidator) Description(ctx context.Context) string {
+	return "value must be a valid semantic version"
+}
+
+func (v minVersionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v minVersionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	ver, err := version.NewSemver(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Version", fmt.Sprintf("%q is not a valid semantic version, %s", value, err.Error()))
+		return
+	}
+	if ver.Prerelease() != "" {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Pre-release Minimum Version", fmt.Sprintf("%q is a pre-release version, pre-releases sort before their final release so any %s build will satisfy this check", value, ver.Core().String()))
+	}
+}
+
+var _ validator.String = ipAddressValidator{}
+
+// ipAddressValidator validates that a string is an IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(ctx context.Context) string {
+	return "value must be a valid IPv4 or IPv6 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := netip.ParseAddr(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP Address", fmt.Sprintf("%q is not a valid IP address, %s", value, err.Error()))
+	}
+}
+
+// canonicalCIDR returns the canonical form of a CIDR range with its host bits cleared, unparsable ranges are returned as-is.
+func canonicalCIDR(s string) string {
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return s
+	}
+	return prefix.Masked().String()
+}
+
+var _ validator.Object = atLeastOneAttributeValidator{}
+
+// atLeastOneAttributeValidator validates that a configured object has at least one of the listed attributes set,
+// other attributes of the object, e.g. an enabled flag, do not count.
+type atLeastOneAttributeValidator struct {
+	attributes []string
+}
+
+func (v atLeastOneAttributeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("at least one of %s must be configured", strings.Join(v.attributes, ", "))
+}
+
+func (v atLeastOneAttributeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v atLeastOneAttributeValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attrs := req.ConfigValue.Attributes()
+	for _, name := range v.attributes {
+		if value, ok := attrs[name]; ok && !value.IsNull() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(req.Path, "Missing Attribute Configuration", fmt.Sprintf("At least one of %s must be configured", strings.Join(v.attributes, ", ")))
+}
+
+var _ validator.String = networkResourceAddressValidator{}
+
+// networkResourceAddressValidator validates that a network resource address is an IP address, a CIDR range or a domain,
+// where domains may only use a wildcard as their leftmost label.
+type networkResourceAddressValidator struct{}
+
+func (v networkResourceAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IP address, a CIDR range or a domain, wildcards are only allowed as the leftmost label"
+}
+
+func (v networkResourceAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkResourceAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := netip.ParseAddr(value); err == nil {
+		return
+	}
+	if _, err := netip.ParsePrefix(value); err == nil {
+		return
+	}
+
+	for i, label := range strings.Split(value, ".") {
+		if label == "" {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Domain", fmt.Sprintf("%q contains an empty label", value))
+			return
+		}
+		if strings.Contains(label, "*") && (i != 0 || label != "*") {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Wildcard Domain", fmt.Sprintf("%q is not a valid wildcard domain, only the leftmost label may be \"*\"", value))
+			return
+		}
+	}
+}
+
+// normalizeNetworkResourceAddress returns the address as sent to the API, domains are case-insensitive and sent in lowercase.
+func normalizeNetworkResourceAddress(s string) string {
+	return strings.ToLower(s)
+}